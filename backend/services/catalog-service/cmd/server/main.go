@@ -1,22 +1,33 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	_ "github.com/lib/pq"
+	"github.com/redis/go-redis/v9"
 	httpSwagger "github.com/swaggo/http-swagger"
 	_ "github.com/tokobapak/catalog-service/docs" // docs is generated by Swag CLI
+	"google.golang.org/grpc"
 
+	_grpc "github.com/tokobapak/catalog-service/internal/delivery/grpc"
 	_http "github.com/tokobapak/catalog-service/internal/delivery/http"
+	"github.com/tokobapak/catalog-service/internal/domain"
+	"github.com/tokobapak/catalog-service/internal/event"
 	"github.com/tokobapak/catalog-service/internal/repository/postgres"
+	_redis "github.com/tokobapak/catalog-service/internal/repository/redis"
 	"github.com/tokobapak/catalog-service/internal/usecase"
+	"github.com/tokobapak/catalog-service/pkg/taxonomy"
 )
 
 func main() {
@@ -43,6 +54,8 @@ func main() {
 	// @BasePath        /api/v1
 
 	r := chi.NewRouter()
+	r.NotFound(_http.NotFound)
+	r.MethodNotAllowed(_http.MethodNotAllowed)
 	
 	// Production middleware stack (Context7 best practices)
 	r.Use(middleware.RequestID)    // Assign unique ID to each request
@@ -51,6 +64,7 @@ func main() {
 	r.Use(middleware.Recoverer)    // Recover from panics, return HTTP 500
 	r.Use(middleware.CleanPath)    // Clean double slashes from URL
 	r.Use(middleware.Timeout(60 * time.Second))
+	r.Use(_http.Actor)             // Record X-User-ID as the author of changes
 
 	// Swagger endpoint (disable in production)
 	if getEnv("NODE_ENV", "development") != "production" {
@@ -59,13 +73,108 @@ func main() {
 
 	timeoutContext := time.Duration(2) * time.Second
 
-	categoryRepo := postgres.NewPostgresCategoryRepository(db)
-	categoryUsecase := usecase.NewCategoryUsecase(categoryRepo, timeoutContext)
-	_http.NewCategoryHandler(r, categoryUsecase)
+	cacheTTL, err := time.ParseDuration(getEnv("CACHE_TTL", "10m"))
+	if err != nil {
+		log.Fatal("Invalid CACHE_TTL", err)
+	}
+
+	var cache _redis.Cache
+	switch driver := getEnv("CACHE_DRIVER", "redis"); driver {
+	case "redis":
+		rdb := redis.NewClient(&redis.Options{
+			Addr:     getEnv("REDIS_HOST", "localhost") + ":" + getEnv("REDIS_PORT", "6379"),
+			Password: getEnv("REDIS_PASSWORD", ""),
+		})
+		if err := rdb.Ping(context.Background()).Err(); err != nil {
+			log.Fatal("Cannot connect to redis", err)
+		}
+		cache = _redis.NewRedisCache(rdb)
+	case "memory":
+		cache = _redis.NewLRUCache(10000)
+	default:
+		log.Fatalf("Unknown CACHE_DRIVER %q", driver)
+	}
+
+	revisionRepo := postgres.NewPostgresRevisionRepository(db)
+
+	categoryRepo := _redis.NewCachedCategoryRepository(postgres.NewPostgresCategoryRepository(db), cache, cacheTTL)
+	categoryUsecase := usecase.NewCategoryUsecase(categoryRepo, revisionRepo, domain.SystemClock{}, timeoutContext)
+
+	brandRepo := _redis.NewCachedBrandRepository(postgres.NewPostgresBrandRepository(db), cache, cacheTTL)
+	brandUsecase := usecase.NewBrandUsecase(brandRepo, categoryRepo, revisionRepo, timeoutContext)
+
+	translationRepo := _redis.NewCachedTranslationRepository(postgres.NewPostgresTranslationRepository(db), categoryRepo, brandRepo, cache)
+	translationUsecase := usecase.NewTranslationUsecase(translationRepo, categoryRepo, brandRepo, timeoutContext)
+
+	_http.NewCategoryHandler(r, categoryUsecase, translationUsecase)
+	_http.NewBrandHandler(r, brandUsecase, translationUsecase)
+	_http.NewTranslationHandler(r, translationUsecase)
+
+	attributeRepo := postgres.NewPostgresAttributeRepository(db)
+	attributeUsecase := usecase.NewAttributeUsecase(attributeRepo, categoryRepo, timeoutContext)
+	_http.NewAttributeHandler(r, attributeUsecase)
+
+	taxonomies, err := taxonomy.Load(getEnv("TAXONOMY_DIR", "taxonomies"))
+	if err != nil {
+		log.Fatal("Cannot load taxonomies", err)
+	}
+	taxonomyUsecase := usecase.NewTaxonomyUsecase(postgres.NewPostgresTaxonomyRepository(db), categoryRepo, taxonomies, timeoutContext)
+	_http.NewTaxonomyHandler(r, taxonomyUsecase)
+
+	feeRuleUsecase := usecase.NewFeeRuleUsecase(postgres.NewPostgresFeeRuleRepository(db), categoryRepo, timeoutContext)
+	_http.NewFeeRuleHandler(r, feeRuleUsecase)
+
+	relayInterval, err := time.ParseDuration(getEnv("OUTBOX_RELAY_INTERVAL", "1s"))
+	if err != nil {
+		log.Fatal("Invalid OUTBOX_RELAY_INTERVAL", err)
+	}
+
+	publisher := event.NewKafkaPublisher(strings.Split(getEnv("KAFKA_BROKERS", "localhost:9092"), ","), getEnv("KAFKA_TOPIC", "catalog.events"))
+	defer publisher.Close()
 
-	brandRepo := postgres.NewPostgresBrandRepository(db)
-	brandUsecase := usecase.NewBrandUsecase(brandRepo, timeoutContext)
-	_http.NewBrandHandler(r, brandUsecase)
+	maxAttempts, err := strconv.Atoi(getEnv("OUTBOX_MAX_ATTEMPTS", "25"))
+	if err != nil || maxAttempts < 1 {
+		log.Fatal("Invalid OUTBOX_MAX_ATTEMPTS", err)
+	}
+	outboxRepo := postgres.NewPostgresOutboxRepository(db, maxAttempts)
+
+	relay := event.NewRelay(outboxRepo, publisher, relayInterval)
+	go relay.Run(context.Background())
+
+	outboxRetention, err := time.ParseDuration(getEnv("OUTBOX_RETENTION", "168h"))
+	if err != nil {
+		log.Fatal("Invalid OUTBOX_RETENTION", err)
+	}
+	cleanupInterval, err := time.ParseDuration(getEnv("OUTBOX_CLEANUP_INTERVAL", "1h"))
+	if err != nil {
+		log.Fatal("Invalid OUTBOX_CLEANUP_INTERVAL", err)
+	}
+
+	cleaner := event.NewOutboxCleaner(outboxRepo, domain.SystemClock{}, outboxRetention, cleanupInterval)
+	go cleaner.Run(context.Background())
+
+	visibilityInterval, err := time.ParseDuration(getEnv("VISIBILITY_SYNC_INTERVAL", "1m"))
+	if err != nil {
+		log.Fatal("Invalid VISIBILITY_SYNC_INTERVAL", err)
+	}
+
+	scheduler := event.NewVisibilityScheduler(categoryRepo, domain.SystemClock{}, visibilityInterval)
+	go scheduler.Run(context.Background())
+
+	grpcServer := grpc.NewServer()
+	_grpc.NewCatalogServer(grpcServer, categoryUsecase, brandUsecase)
+
+	grpcPort := getEnv("GRPC_PORT", "9002")
+	lis, err := net.Listen("tcp", ":"+grpcPort)
+	if err != nil {
+		log.Fatal(err)
+	}
+	go func() {
+		fmt.Printf("Catalog gRPC server started on port %s\n", grpcPort)
+		if err := grpcServer.Serve(lis); err != nil {
+			log.Fatal(err)
+		}
+	}()
 
 	port := getEnv("PORT", "3002")
 	fmt.Printf("Catalog Service started on port %s\n", port)
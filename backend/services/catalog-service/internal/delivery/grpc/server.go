@@ -0,0 +1,143 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	catalogv1 "github.com/tokobapak/catalog-service/api/proto/catalog/v1"
+	"github.com/tokobapak/catalog-service/internal/domain"
+)
+
+type CatalogServer struct {
+	catalogv1.UnimplementedCatalogServiceServer
+	CUsecase domain.CategoryUsecase
+	BUsecase domain.BrandUsecase
+}
+
+func NewCatalogServer(s *grpc.Server, cu domain.CategoryUsecase, bu domain.BrandUsecase) {
+	catalogv1.RegisterCatalogServiceServer(s, &CatalogServer{
+		CUsecase: cu,
+		BUsecase: bu,
+	})
+}
+
+func (s *CatalogServer) GetCategory(ctx context.Context, req *catalogv1.GetCategoryRequest) (*catalogv1.Category, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	c, err := s.CUsecase.GetByID(ctx, req.GetId(), false)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+
+	return toCategoryProto(c), nil
+}
+
+func (s *CatalogServer) BatchGetCategories(ctx context.Context, req *catalogv1.BatchGetCategoriesRequest) (*catalogv1.BatchGetCategoriesResponse, error) {
+	list, missing, err := s.CUsecase.BatchGet(ctx, req.GetIds())
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+
+	res := &catalogv1.BatchGetCategoriesResponse{
+		Categories: make([]*catalogv1.Category, 0, len(list)),
+		MissingIds: missing,
+	}
+	for _, c := range list {
+		res.Categories = append(res.Categories, toCategoryProto(c))
+	}
+
+	return res, nil
+}
+
+func (s *CatalogServer) GetTree(ctx context.Context, req *catalogv1.GetTreeRequest) (*catalogv1.GetTreeResponse, error) {
+	roots, err := s.CUsecase.GetTree(ctx, int(req.GetMaxDepth()), req.GetActiveOnly(), false)
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+
+	res := &catalogv1.GetTreeResponse{Roots: make([]*catalogv1.Category, 0, len(roots))}
+	for _, c := range roots {
+		res.Roots = append(res.Roots, toCategoryProto(c))
+	}
+
+	return res, nil
+}
+
+func (s *CatalogServer) GetBrand(ctx context.Context, req *catalogv1.GetBrandRequest) (*catalogv1.Brand, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	b, err := s.BUsecase.GetByID(ctx, req.GetId())
+	if err != nil {
+		return nil, toStatus(ctx, err)
+	}
+
+	return toBrandProto(b), nil
+}
+
+func toCategoryProto(c domain.Category) *catalogv1.Category {
+	pc := &catalogv1.Category{
+		Id:           c.ID,
+		Name:         c.Name,
+		Slug:         c.Slug,
+		Description:  c.Description,
+		ParentId:     c.ParentID,
+		ImageUrl:     c.ImageURL,
+		IconUrl:      c.IconURL,
+		DisplayOrder: int32(c.DisplayOrder),
+		IsActive:     c.IsActive,
+		CreatedAt:    timestamppb.New(c.CreatedAt),
+		UpdatedAt:    timestamppb.New(c.UpdatedAt),
+	}
+
+	for _, child := range c.Children {
+		pc.Children = append(pc.Children, toCategoryProto(child))
+	}
+
+	return pc
+}
+
+func toBrandProto(b domain.Brand) *catalogv1.Brand {
+	return &catalogv1.Brand{
+		Id:        b.ID,
+		Name:      b.Name,
+		Slug:      b.Slug,
+		LogoUrl:   b.LogoURL,
+		IsActive:  b.IsActive,
+		CreatedAt: timestamppb.New(b.CreatedAt),
+		UpdatedAt: timestamppb.New(b.UpdatedAt),
+	}
+}
+
+// toStatus maps domain errors onto gRPC status codes. Unexpected errors are
+// logged and answered with a generic message, so that driver and SQL details
+// stay on the server.
+func toStatus(ctx context.Context, err error) error {
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrBadParamInput), errors.Is(err, domain.ErrValidation):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrConflict):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, domain.ErrVersionMismatch):
+		return status.Error(codes.Aborted, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	default:
+		method, _ := grpc.Method(ctx)
+		log.Printf("%s: %v", method, err)
+		return status.Error(codes.Internal, domain.ErrInternalServerError.Error())
+	}
+}
@@ -0,0 +1,139 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	catalogv1 "github.com/tokobapak/catalog-service/api/proto/catalog/v1"
+	"github.com/tokobapak/catalog-service/internal/domain"
+)
+
+func TestToStatus(t *testing.T) {
+	cases := []struct {
+		err     error
+		code    codes.Code
+		message string
+	}{
+		{domain.ErrNotFound, codes.NotFound, domain.ErrNotFound.Error()},
+		{fmt.Errorf("%w: unknown delete policy", domain.ErrBadParamInput), codes.InvalidArgument, "given param is not valid: unknown delete policy"},
+		{domain.ErrValidation, codes.InvalidArgument, domain.ErrValidation.Error()},
+		{domain.ErrConflict, codes.AlreadyExists, domain.ErrConflict.Error()},
+		{domain.ErrVersionMismatch, codes.Aborted, domain.ErrVersionMismatch.Error()},
+		{context.DeadlineExceeded, codes.DeadlineExceeded, context.DeadlineExceeded.Error()},
+		{context.Canceled, codes.Canceled, context.Canceled.Error()},
+		// Driver errors must not reach clients.
+		{errors.New(`pq: relation "categories" does not exist`), codes.Internal, domain.ErrInternalServerError.Error()},
+	}
+	for _, tc := range cases {
+		st := status.Convert(toStatus(context.Background(), tc.err))
+		if st.Code() != tc.code || st.Message() != tc.message {
+			t.Errorf("%v: expected %s %q, got %s %q", tc.err, tc.code, tc.message, st.Code(), st.Message())
+		}
+	}
+}
+
+// stubCategories serves a fixed set of categories by ID.
+type stubCategories struct {
+	domain.CategoryUsecase
+	byID map[string]domain.Category
+}
+
+func (s stubCategories) GetByID(_ context.Context, id string, includeScheduled bool) (domain.Category, error) {
+	c, ok := s.byID[id]
+	if !ok || includeScheduled {
+		return domain.Category{}, domain.ErrNotFound
+	}
+	return c, nil
+}
+
+func (s stubCategories) BatchGet(_ context.Context, ids []string) ([]domain.Category, []string, error) {
+	var (
+		found   []domain.Category
+		missing []string
+	)
+	for _, id := range ids {
+		if c, ok := s.byID[id]; ok {
+			found = append(found, c)
+		} else {
+			missing = append(missing, id)
+		}
+	}
+	return found, missing, nil
+}
+
+// dial serves a CatalogServer for cu in memory and returns a client for it.
+func dial(t *testing.T, cu domain.CategoryUsecase) catalogv1.CatalogServiceClient {
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	NewCatalogServer(s, cu, nil)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return catalogv1.NewCatalogServiceClient(conn)
+}
+
+func TestGetCategory(t *testing.T) {
+	ctx := context.Background()
+	client := dial(t, stubCategories{byID: map[string]domain.Category{
+		"elektronik": {ID: "elektronik", Name: "Elektronik", Slug: "elektronik", IsActive: true},
+	}})
+
+	c, err := client.GetCategory(ctx, &catalogv1.GetCategoryRequest{Id: "elektronik"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.GetId() != "elektronik" || c.GetName() != "Elektronik" || !c.GetIsActive() {
+		t.Errorf("unexpected category %+v", c)
+	}
+
+	_, err = client.GetCategory(ctx, &catalogv1.GetCategoryRequest{Id: "missing"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound, got %v", err)
+	}
+
+	_, err = client.GetCategory(ctx, &catalogv1.GetCategoryRequest{})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument for an empty id, got %v", err)
+	}
+}
+
+func TestBatchGetCategories(t *testing.T) {
+	client := dial(t, stubCategories{byID: map[string]domain.Category{
+		"elektronik": {ID: "elektronik"},
+		"fashion":    {ID: "fashion"},
+	}})
+
+	res, err := client.BatchGetCategories(context.Background(), &catalogv1.BatchGetCategoriesRequest{Ids: []string{"fashion", "missing", "elektronik"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ids []string
+	for _, c := range res.GetCategories() {
+		ids = append(ids, c.GetId())
+	}
+	if strings.Join(ids, ",") != "fashion,elektronik" {
+		t.Errorf("expected fashion and elektronik in request order, got %v", ids)
+	}
+	if missing := res.GetMissingIds(); len(missing) != 1 || missing[0] != "missing" {
+		t.Errorf("expected missing to be reported, got %v", missing)
+	}
+}
@@ -0,0 +1,163 @@
+package http
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/tokobapak/catalog-service/internal/domain"
+)
+
+type AttributeHandler struct {
+	AUsecase domain.AttributeUsecase
+}
+
+func NewAttributeHandler(r *chi.Mux, us domain.AttributeUsecase) {
+	handler := &AttributeHandler{
+		AUsecase: us,
+	}
+
+	r.Route("/api/v1/attributes", func(r chi.Router) {
+		r.Get("/", handler.Fetch)
+		r.Get("/{id}", handler.GetByID)
+		r.Post("/", handler.Store)
+		r.Put("/{id}", handler.Update)
+		r.Delete("/{id}", handler.Delete)
+	})
+
+	r.Get("/api/v1/categories/{id}/attributes", handler.GetEffective)
+	r.Put("/api/v1/categories/{id}/attributes/{attributeId}", handler.Attach)
+	r.Delete("/api/v1/categories/{id}/attributes/{attributeId}", handler.Detach)
+}
+
+// Fetch godoc
+// @Summary List attributes
+// @Description Get all attribute definitions
+// @Tags attributes
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Router /attributes [get]
+func (a *AttributeHandler) Fetch(w http.ResponseWriter, r *http.Request) {
+	list, err := a.AUsecase.Fetch(r.Context())
+	if err != nil {
+		respondErr(w, r, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"data": list,
+	})
+}
+
+func (a *AttributeHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	attr, err := a.AUsecase.GetByID(r.Context(), id)
+	if err != nil {
+		respondErr(w, r, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, attr)
+}
+
+func (a *AttributeHandler) Store(w http.ResponseWriter, r *http.Request) {
+	var attr domain.Attribute
+	if err := json.NewDecoder(r.Body).Decode(&attr); err != nil {
+		respondError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := a.AUsecase.Store(r.Context(), &attr); err != nil {
+		respondErr(w, r, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, attr)
+}
+
+func (a *AttributeHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	var attr domain.Attribute
+	if err := json.NewDecoder(r.Body).Decode(&attr); err != nil {
+		respondError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	attr.ID = id
+	if err := a.AUsecase.Update(r.Context(), &attr); err != nil {
+		respondErr(w, r, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, attr)
+}
+
+func (a *AttributeHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	if err := a.AUsecase.Delete(r.Context(), id); err != nil {
+		respondErr(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetEffective godoc
+// @Summary Effective category attributes
+// @Description Get the attributes that apply to a category, including those inherited from its ancestors
+// @Tags attributes
+// @Produce json
+// @Param id path string true "Category ID"
+// @Success 200 {object} map[string]interface{}
+// @Router /categories/{id}/attributes [get]
+func (a *AttributeHandler) GetEffective(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	list, err := a.AUsecase.GetEffective(r.Context(), id)
+	if err != nil {
+		respondErr(w, r, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"data": list,
+	})
+}
+
+type attachAttributeRequest struct {
+	IsRequired *bool `json:"isRequired"`
+}
+
+func (a *AttributeHandler) Attach(w http.ResponseWriter, r *http.Request) {
+	categoryID := chi.URLParam(r, "id")
+	attributeID := chi.URLParam(r, "attributeId")
+
+	// The body is optional; an empty one attaches without overriding isRequired.
+	var req attachAttributeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		respondError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := a.AUsecase.Attach(r.Context(), categoryID, attributeID, req.IsRequired); err != nil {
+		respondErr(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *AttributeHandler) Detach(w http.ResponseWriter, r *http.Request) {
+	categoryID := chi.URLParam(r, "id")
+	attributeID := chi.URLParam(r, "attributeId")
+
+	if err := a.AUsecase.Detach(r.Context(), categoryID, attributeID); err != nil {
+		respondErr(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -3,7 +3,6 @@ package http
 import (
 	"encoding/json"
 	"net/http"
-	"strconv"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/tokobapak/catalog-service/internal/domain"
@@ -11,20 +10,34 @@ import (
 
 type BrandHandler struct {
 	BUsecase domain.BrandUsecase
+	TUsecase domain.TranslationUsecase
 }
 
-func NewBrandHandler(r *chi.Mux, us domain.BrandUsecase) {
+func NewBrandHandler(r *chi.Mux, us domain.BrandUsecase, tu domain.TranslationUsecase) {
 	handler := &BrandHandler{
 		BUsecase: us,
+		TUsecase: tu,
 	}
 
 	r.Route("/api/v1/brands", func(r chi.Router) {
 		r.Get("/", handler.Fetch)
+		r.Get("/slug/{slug}", handler.GetBySlug)
 		r.Get("/{id}", handler.GetByID)
 		r.Post("/", handler.Store)
 		r.Put("/{id}", handler.Update)
+		r.Patch("/{id}", handler.Patch)
 		r.Delete("/{id}", handler.Delete)
+		r.Post("/{id}/restore", handler.Restore)
+		r.Post("/{id}/merge", handler.Merge)
+		r.Get("/{id}/history", handler.History)
+		r.Post("/{id}/history/{revisionId}/revert", handler.Revert)
+		r.Get("/{id}/categories", handler.GetCategories)
+		r.Put("/{id}/categories/{categoryId}", handler.AttachCategory)
+		r.Delete("/{id}/categories/{categoryId}", handler.DetachCategory)
 	})
+
+	r.Get("/api/v1/categories/{id}/brands", handler.FetchByCategory)
+	r.Post("/api/v1/brands:batchGet", handler.BatchGet)
 }
 
 // Fetch godoc
@@ -32,77 +45,508 @@ func NewBrandHandler(r *chi.Mux, us domain.BrandUsecase) {
 // @Description Get all brands with cursor pagination
 // @Tags brands
 // @Produce json
-// @Param cursor query string false "Cursor for pagination"
-// @Param num query int false "Number of items to return"
+// @Param cursor query string false "Opaque cursor from nextCursor or prevCursor"
+// @Param num query int false "Number of items to return (default 10, max 100)"
+// @Param includeDeleted query bool false "Include soft-deleted brands (admin)"
+// @Param isActive query bool false "Only active or only inactive brands"
+// @Param q query string false "Search names and slugs by prefix, and names by similarity"
+// @Param sort query string false "Sort by name or createdAt (default)"
+// @Param order query string false "asc (default) or desc"
 // @Success 200 {object} map[string]interface{}
 // @Router /brands [get]
 func (a *BrandHandler) Fetch(w http.ResponseWriter, r *http.Request) {
-	cursor := r.URL.Query().Get("cursor")
-	numS := r.URL.Query().Get("num")
-	num, _ := strconv.ParseInt(numS, 10, 64)
+	num, filter, err := brandListParams(r)
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, domain.ErrBadParamInput.Error())
+		return
+	}
+
+	list, page, err := a.BUsecase.Fetch(r.Context(), filter, r.URL.Query().Get("cursor"), num)
+	if err != nil {
+		respondErr(w, r, err)
+		return
+	}
+
+	a.respondPage(w, r, list, page)
+}
+
+// FetchByCategory godoc
+// @Summary List brands in a category
+// @Description Get the brands associated with a category and, unless includeDescendants is false, with its descendants, with cursor pagination
+// @Tags brands
+// @Produce json
+// @Param id path string true "Category ID"
+// @Param includeDescendants query bool false "Include brands of descendant categories (default true)"
+// @Param cursor query string false "Opaque cursor from nextCursor or prevCursor"
+// @Param num query int false "Number of items to return (default 10, max 100)"
+// @Param isActive query bool false "Only active or only inactive brands"
+// @Param q query string false "Search names and slugs by prefix, and names by similarity"
+// @Param sort query string false "Sort by name or createdAt (default)"
+// @Param order query string false "asc (default) or desc"
+// @Success 200 {object} map[string]interface{}
+// @Router /categories/{id}/brands [get]
+func (a *BrandHandler) FetchByCategory(w http.ResponseWriter, r *http.Request) {
+	num, filter, err := brandListParams(r)
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, domain.ErrBadParamInput.Error())
+		return
+	}
+
+	includeDescendants, err := queryOptionalBool(r, "includeDescendants")
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, domain.ErrBadParamInput.Error())
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+	deep := includeDescendants == nil || *includeDescendants
+	list, page, err := a.BUsecase.FetchByCategory(r.Context(), id, deep, filter, r.URL.Query().Get("cursor"), num)
+	if err != nil {
+		respondErr(w, r, err)
+		return
+	}
+
+	a.respondPage(w, r, list, page)
+}
 
-	list, nextCursor, err := a.BUsecase.Fetch(r.Context(), cursor, num)
+// brandListParams reads the page size and filter shared by the brand listings.
+func brandListParams(r *http.Request) (int64, domain.BrandFilter, error) {
+	num, err := queryInt(r, "num")
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, err.Error())
+		return 0, domain.BrandFilter{}, err
+	}
+
+	includeDeleted, err := queryBool(r, "includeDeleted")
+	if err != nil {
+		return 0, domain.BrandFilter{}, err
+	}
+
+	isActive, err := queryOptionalBool(r, "isActive")
+	if err != nil {
+		return 0, domain.BrandFilter{}, err
+	}
+
+	sort, err := querySort(r)
+	if err != nil {
+		return 0, domain.BrandFilter{}, err
+	}
+
+	return num, domain.BrandFilter{
+		IncludeDeleted: includeDeleted,
+		IsActive:       isActive,
+		Query:          r.URL.Query().Get("q"),
+		Sort:           sort,
+	}, nil
+}
+
+func (a *BrandHandler) respondPage(w http.ResponseWriter, r *http.Request, list []domain.Brand, page domain.PageInfo) {
+	if err := a.localize(w, r, list); err != nil {
+		respondErr(w, r, err)
 		return
 	}
 
 	respondJSON(w, http.StatusOK, map[string]interface{}{
 		"data":       list,
-		"nextCursor": nextCursor,
+		"nextCursor": page.NextCursor,
+		"prevCursor": page.PrevCursor,
+		"hasMore":    page.HasMore,
+		"total":      page.Total,
 	})
 }
 
+// GetBySlug godoc
+// @Summary Get brand by slug
+// @Description Get a brand by its current slug or a former one. A former slug answers with a Link header pointing at the canonical URL.
+// @Tags brands
+// @Produce json
+// @Param slug path string true "Brand slug"
+// @Success 200 {object} domain.Brand
+// @Router /brands/slug/{slug} [get]
+func (a *BrandHandler) GetBySlug(w http.ResponseWriter, r *http.Request) {
+	brand, moved, err := a.BUsecase.ResolveSlug(r.Context(), chi.URLParam(r, "slug"))
+	if err != nil {
+		respondErr(w, r, err)
+		return
+	}
+
+	if moved {
+		setCanonicalLink(w, "/api/v1/brands/slug/"+brand.Slug)
+	}
+
+	if err := a.localizeOne(w, r, &brand); err != nil {
+		respondErr(w, r, err)
+		return
+	}
+	respondTagged(w, r, etag(brand.Version, brand.Locale), brand)
+}
+
+// GetByID godoc
+// @Summary Get brand
+// @Description Get a brand by ID. The ETag changes with every write to the brand or its translations; send it back in If-None-Match to get 304 when nothing changed.
+// @Tags brands
+// @Produce json
+// @Param id path string true "Brand ID"
+// @Param If-None-Match header string false "ETag from a previous read"
+// @Success 200 {object} domain.Brand
+// @Success 304
+// @Router /brands/{id} [get]
 func (a *BrandHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 	ctx := r.Context()
 
 	cat, err := a.BUsecase.GetByID(ctx, id)
 	if err != nil {
-		respondError(w, http.StatusNotFound, domain.ErrNotFound.Error())
+		respondErr(w, r, err)
 		return
 	}
 
-	respondJSON(w, http.StatusOK, cat)
+	if err := a.localizeOne(w, r, &cat); err != nil {
+		respondErr(w, r, err)
+		return
+	}
+
+	respondTagged(w, r, etag(cat.Version, cat.Locale), cat)
+}
+
+// BatchGet godoc
+// @Summary Batch get brands
+// @Description Get up to 100 brands by ID in one call; unknown IDs are listed in missingIds
+// @Tags brands
+// @Accept json
+// @Produce json
+// @Param request body batchGetRequest true "Brand IDs"
+// @Success 200 {object} map[string]interface{}
+// @Router /brands:batchGet [post]
+func (a *BrandHandler) BatchGet(w http.ResponseWriter, r *http.Request) {
+	var req batchGetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	list, missing, err := a.BUsecase.BatchGet(r.Context(), req.IDs)
+	if err != nil {
+		respondErr(w, r, err)
+		return
+	}
+
+	if err := a.localize(w, r, list); err != nil {
+		respondErr(w, r, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"data":       list,
+		"missingIds": missing,
+	})
 }
 
 func (a *BrandHandler) Store(w http.ResponseWriter, r *http.Request) {
 	var brand domain.Brand
 	if err := json.NewDecoder(r.Body).Decode(&brand); err != nil {
-		respondError(w, http.StatusBadRequest, err.Error())
+		respondError(w, r, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	if err := a.BUsecase.Store(r.Context(), &brand); err != nil {
-		respondError(w, http.StatusInternalServerError, err.Error())
+		respondErr(w, r, err)
 		return
 	}
 
+	setETag(w, brand.Version, "")
 	respondJSON(w, http.StatusCreated, brand)
 }
 
+// Update godoc
+// @Summary Update brand
+// @Description Replace a brand. If-Match must carry the ETag of the version being edited; a brand changed since answers 412.
+// @Tags brands
+// @Accept json
+// @Produce json
+// @Param id path string true "Brand ID"
+// @Param If-Match header string true "ETag from the last read, or * to overwrite unconditionally"
+// @Param request body domain.Brand true "Brand"
+// @Success 200 {object} domain.Brand
+// @Router /brands/{id} [put]
 func (a *BrandHandler) Update(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		respondErr(w, r, err)
+		return
+	}
+
 	var brand domain.Brand
 	if err := json.NewDecoder(r.Body).Decode(&brand); err != nil {
-		respondError(w, http.StatusBadRequest, err.Error())
+		respondError(w, r, http.StatusBadRequest, err.Error())
 		return
 	}
-	
+
 	brand.ID = id
+	brand.Version = version
 	if err := a.BUsecase.Update(r.Context(), &brand); err != nil {
-		respondError(w, http.StatusInternalServerError, err.Error())
+		respondErr(w, r, err)
 		return
 	}
 
+	setETag(w, brand.Version, "")
 	respondJSON(w, http.StatusOK, brand)
 }
 
+// Patch godoc
+// @Summary Patch brand
+// @Description Change some fields of a brand with a JSON merge patch (RFC 7396): absent members are left alone and null clears logoUrl. If-Match must carry the ETag of the version being edited; a brand changed since answers 412.
+// @Tags brands
+// @Accept application/merge-patch+json
+// @Produce json
+// @Param id path string true "Brand ID"
+// @Param If-Match header string true "ETag from the last read, or * to patch unconditionally"
+// @Param request body domain.BrandPatch true "Merge patch"
+// @Success 200 {object} domain.Brand
+// @Router /brands/{id} [patch]
+func (a *BrandHandler) Patch(w http.ResponseWriter, r *http.Request) {
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		respondErr(w, r, err)
+		return
+	}
+
+	var patch domain.BrandPatch
+	if err := decodeMergePatch(r, &patch); err != nil {
+		respondErr(w, r, err)
+		return
+	}
+
+	brand, err := a.BUsecase.Patch(r.Context(), chi.URLParam(r, "id"), version, patch)
+	if err != nil {
+		respondErr(w, r, err)
+		return
+	}
+
+	setETag(w, brand.Version, "")
+	respondJSON(w, http.StatusOK, brand)
+}
+
+// Delete godoc
+// @Summary Delete brand
+// @Description Soft-delete a brand. If-Match must carry the ETag of the version being deleted; a brand changed since answers 412.
+// @Tags brands
+// @Param id path string true "Brand ID"
+// @Param If-Match header string true "ETag from the last read, or * to delete unconditionally"
+// @Success 204
+// @Router /brands/{id} [delete]
 func (a *BrandHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
-	
-	if err := a.BUsecase.Delete(r.Context(), id); err != nil {
-		respondError(w, http.StatusInternalServerError, err.Error())
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		respondErr(w, r, err)
+		return
+	}
+
+	if err := a.BUsecase.Delete(r.Context(), id, version); err != nil {
+		respondErr(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Restore godoc
+// @Summary Restore brand
+// @Description Undelete a soft-deleted brand
+// @Tags brands
+// @Produce json
+// @Param id path string true "Brand ID"
+// @Success 200 {object} domain.Brand
+// @Router /brands/{id}/restore [post]
+func (a *BrandHandler) Restore(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	brand, err := a.BUsecase.Restore(r.Context(), id)
+	if err != nil {
+		respondErr(w, r, err)
+		return
+	}
+
+	setETag(w, brand.Version, "")
+	respondJSON(w, http.StatusOK, brand)
+}
+
+// Merge godoc
+// @Summary Merge brand
+// @Description Fold a duplicate brand into another one, which takes over its categories and its slug. The duplicate is soft-deleted with mergedInto set. If-Match must carry the ETag of the duplicate.
+// @Tags brands
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of the duplicate brand"
+// @Param If-Match header string true "ETag of the duplicate from the last read, or * to merge unconditionally"
+// @Param request body mergeRequest true "ID of the surviving brand"
+// @Success 200 {object} domain.Brand
+// @Router /brands/{id}/merge [post]
+func (a *BrandHandler) Merge(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		respondErr(w, r, err)
+		return
+	}
+
+	var req mergeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	if req.Into == "" {
+		respondError(w, r, http.StatusBadRequest, "into must name the surviving brand")
+		return
+	}
+
+	brand, err := a.BUsecase.Merge(r.Context(), id, req.Into, version)
+	if err != nil {
+		respondErr(w, r, err)
+		return
+	}
+
+	setETag(w, brand.Version, "")
+	respondJSON(w, http.StatusOK, brand)
+}
+
+// History godoc
+// @Summary Brand revision history
+// @Description List the recorded changes of a brand, newest first, with the actor and a field-by-field diff
+// @Tags brands
+// @Produce json
+// @Param id path string true "Brand ID"
+// @Param cursor query string false "Opaque cursor from nextCursor or prevCursor"
+// @Param num query int false "Number of items to return (default 10, max 100)"
+// @Success 200 {object} map[string]interface{}
+// @Router /brands/{id}/history [get]
+func (a *BrandHandler) History(w http.ResponseWriter, r *http.Request) {
+	num, err := queryInt(r, "num")
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, domain.ErrBadParamInput.Error())
+		return
+	}
+
+	list, page, err := a.BUsecase.History(r.Context(), chi.URLParam(r, "id"), r.URL.Query().Get("cursor"), num)
+	if err != nil {
+		respondErr(w, r, err)
+		return
+	}
+
+	respondRevisions(w, list, page)
+}
+
+// Revert godoc
+// @Summary Revert brand
+// @Description Restore the fields of a brand to how a revision left them
+// @Tags brands
+// @Produce json
+// @Param id path string true "Brand ID"
+// @Param revisionId path int true "Revision ID"
+// @Success 200 {object} domain.Brand
+// @Router /brands/{id}/history/{revisionId}/revert [post]
+func (a *BrandHandler) Revert(w http.ResponseWriter, r *http.Request) {
+	revisionID, err := revisionIDParam(r)
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, domain.ErrBadParamInput.Error())
+		return
+	}
+
+	brand, err := a.BUsecase.Revert(r.Context(), chi.URLParam(r, "id"), revisionID)
+	if err != nil {
+		respondErr(w, r, err)
+		return
+	}
+
+	setETag(w, brand.Version, "")
+	respondJSON(w, http.StatusOK, brand)
+}
+
+// localize translates list for the client's Accept-Language and describes
+// the result in the response headers.
+func (a *BrandHandler) localize(w http.ResponseWriter, r *http.Request, list []domain.Brand) error {
+	if err := a.TUsecase.LocalizeBrands(r.Context(), r.Header.Get("Accept-Language"), list); err != nil {
+		return err
+	}
+
+	locales := make([]string, len(list))
+	for i, b := range list {
+		locales[i] = b.Locale
+	}
+
+	setContentLanguage(w, locales)
+	return nil
+}
+
+func (a *BrandHandler) localizeOne(w http.ResponseWriter, r *http.Request, b *domain.Brand) error {
+	list := []domain.Brand{*b}
+	if err := a.localize(w, r, list); err != nil {
+		return err
+	}
+	*b = list[0]
+	return nil
+}
+
+// GetCategories godoc
+// @Summary Brand categories
+// @Description Get the categories a brand is associated with directly
+// @Tags brands
+// @Produce json
+// @Param id path string true "Brand ID"
+// @Success 200 {object} map[string]interface{}
+// @Router /brands/{id}/categories [get]
+func (a *BrandHandler) GetCategories(w http.ResponseWriter, r *http.Request) {
+	list, err := a.BUsecase.GetCategories(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		respondErr(w, r, err)
+		return
+	}
+
+	if err := a.TUsecase.LocalizeCategories(r.Context(), r.Header.Get("Accept-Language"), list); err != nil {
+		respondErr(w, r, err)
+		return
+	}
+
+	locales := make([]string, len(list))
+	for i, c := range list {
+		locales[i] = c.Locale
+	}
+	setContentLanguage(w, locales)
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"data": list,
+	})
+}
+
+// AttachCategory godoc
+// @Summary Attach brand to category
+// @Description Associate a brand with a category; attaching twice is harmless
+// @Tags brands
+// @Param id path string true "Brand ID"
+// @Param categoryId path string true "Category ID"
+// @Success 204
+// @Router /brands/{id}/categories/{categoryId} [put]
+func (a *BrandHandler) AttachCategory(w http.ResponseWriter, r *http.Request) {
+	if err := a.BUsecase.AttachCategory(r.Context(), chi.URLParam(r, "id"), chi.URLParam(r, "categoryId")); err != nil {
+		respondErr(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DetachCategory godoc
+// @Summary Detach brand from category
+// @Description Remove the association between a brand and a category
+// @Tags brands
+// @Param id path string true "Brand ID"
+// @Param categoryId path string true "Category ID"
+// @Success 204
+// @Router /brands/{id}/categories/{categoryId} [delete]
+func (a *BrandHandler) DetachCategory(w http.ResponseWriter, r *http.Request) {
+	if err := a.BUsecase.DetachCategory(r.Context(), chi.URLParam(r, "id"), chi.URLParam(r, "categoryId")); err != nil {
+		respondErr(w, r, err)
 		return
 	}
 
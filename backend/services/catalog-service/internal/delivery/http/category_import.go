@@ -0,0 +1,314 @@
+package http
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5/middleware"
+
+	"github.com/tokobapak/catalog-service/internal/domain"
+)
+
+// Media types of category import and export files.
+const (
+	CSVContentType       = "text/csv"
+	JSONLinesContentType = "application/x-ndjson"
+)
+
+// maxImportBytes caps the size of an import file.
+const maxImportBytes = 32 << 20
+
+// errUnsupportedImport answers an import that is neither CSV nor JSON Lines.
+var errUnsupportedImport = errors.New("imports must be " + CSVContentType + " or " + JSONLinesContentType)
+
+// csvColumns are the columns of a category CSV file. Imports may leave out
+// any but path and name, and order them as they like.
+var csvColumns = []string{"path", "name", "description", "imageUrl", "iconUrl", "displayOrder", "isActive"}
+
+// Import godoc
+// @Summary Import categories
+// @Description Create and update categories from a CSV or JSON Lines file. Each record names its category by slug path, e.g. elektronik/handphone/android; the parent must exist or come earlier in the path order. Either every record is applied in one transaction or none is: a file with any failing record answers 422 with the report. With dryRun the report says what would happen without writing anything. Empty CSV cells and absent JSON members keep the current value.
+// @Tags categories
+// @Accept text/csv
+// @Accept application/x-ndjson
+// @Produce json
+// @Param dryRun query bool false "Only report what the import would do"
+// @Success 200 {object} domain.ImportReport
+// @Failure 422 {object} domain.ImportReport
+// @Router /categories/import [post]
+func (a *CategoryHandler) Import(w http.ResponseWriter, r *http.Request) {
+	dryRun, err := queryBool(r, "dryRun")
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, domain.ErrBadParamInput.Error())
+		return
+	}
+
+	records, err := readCategoryRecords(http.MaxBytesReader(w, r.Body, maxImportBytes), r.Header.Get("Content-Type"))
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		respondError(w, r, http.StatusRequestEntityTooLarge, fmt.Sprintf("imports must not exceed %d bytes", tooLarge.Limit))
+		return
+	}
+	if err != nil {
+		respondErr(w, r, err)
+		return
+	}
+
+	report, err := a.CUsecase.Import(r.Context(), records, dryRun)
+	if err != nil {
+		respondErr(w, r, err)
+		return
+	}
+
+	status := http.StatusOK
+	if report.Failed > 0 {
+		status = http.StatusUnprocessableEntity
+	}
+	respondJSON(w, status, report)
+}
+
+// Export godoc
+// @Summary Export categories
+// @Description Stream every category, parents before children, in the format Import reads.
+// @Tags categories
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Param format query string false "csv (default) or jsonl"
+// @Success 200 {string} string
+// @Router /categories/export [get]
+func (a *CategoryHandler) Export(w http.ResponseWriter, r *http.Request) {
+	var (
+		contentType, filename string
+		write                 func(io.Writer) func(domain.CategoryRecord) error
+	)
+	switch r.URL.Query().Get("format") {
+	case "", "csv":
+		contentType, filename, write = CSVContentType, "categories.csv", writeCSVRecords
+	case "jsonl":
+		contentType, filename, write = JSONLinesContentType, "categories.jsonl", writeJSONLRecords
+	default:
+		respondError(w, r, http.StatusBadRequest, "format must be csv or jsonl")
+		return
+	}
+
+	// The status is only committed once the first buffer of records is
+	// written, so a failure to start the export still answers with a problem.
+	out := &lazyWriter{w: w, header: func() {
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+	}}
+	bw := bufio.NewWriter(out)
+	err := a.CUsecase.Export(r.Context(), write(bw))
+	if err == nil {
+		err = bw.Flush()
+	}
+	switch {
+	case err == nil && !out.started:
+		out.header()
+		w.WriteHeader(http.StatusOK)
+	case err != nil && !out.started:
+		respondErr(w, r, err)
+	case err != nil:
+		// Too late for a problem: cutting the stream short is all that is left.
+		log.Printf("[%s] %s %s: export aborted: %v", middleware.GetReqID(r.Context()), r.Method, r.URL.Path, err)
+	}
+}
+
+// lazyWriter calls header before its first write.
+type lazyWriter struct {
+	w       io.Writer
+	header  func()
+	started bool
+}
+
+func (l *lazyWriter) Write(p []byte) (int, error) {
+	if !l.started {
+		l.header()
+		l.started = true
+	}
+	return l.w.Write(p)
+}
+
+// readCategoryRecords parses an import file of the given content type.
+func readCategoryRecords(body io.Reader, contentType string) ([]domain.CategoryRecord, error) {
+	mt, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, errUnsupportedImport
+	}
+	switch mt {
+	case CSVContentType:
+		return readCSVRecords(body)
+	case JSONLinesContentType, "application/jsonl":
+		return readJSONLRecords(body)
+	default:
+		return nil, errUnsupportedImport
+	}
+}
+
+func readCSVRecords(body io.Reader) ([]domain.CategoryRecord, error) {
+	cr := csv.NewReader(body)
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("%w: the file is empty", domain.ErrBadParamInput)
+	}
+	if err != nil {
+		return nil, badImportFile(err)
+	}
+
+	column := make(map[string]int, len(header))
+	for i, name := range header {
+		if !knownColumn(name) {
+			return nil, fmt.Errorf("%w: line 1: unknown column %q", domain.ErrBadParamInput, name)
+		}
+		if _, dup := column[name]; dup {
+			return nil, fmt.Errorf("%w: line 1: column %q appears twice", domain.ErrBadParamInput, name)
+		}
+		column[name] = i
+	}
+	for _, name := range csvColumns[:2] {
+		if _, ok := column[name]; !ok {
+			return nil, fmt.Errorf("%w: line 1: column %q is missing", domain.ErrBadParamInput, name)
+		}
+	}
+
+	var records []domain.CategoryRecord
+	for {
+		row, err := cr.Read()
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return nil, badImportFile(err)
+		}
+		line, _ := cr.FieldPos(0)
+		if len(records) == domain.MaxImportRows {
+			return nil, fmt.Errorf("%w: line %d: at most %d records per import", domain.ErrBadParamInput, line, domain.MaxImportRows)
+		}
+
+		cell := func(name string) *string {
+			i, ok := column[name]
+			if !ok || row[i] == "" {
+				return nil
+			}
+			return &row[i]
+		}
+		rec := domain.CategoryRecord{
+			Line:        line,
+			Path:        row[column["path"]],
+			Name:        row[column["name"]],
+			Description: cell("description"),
+			ImageURL:    cell("imageUrl"),
+			IconURL:     cell("iconUrl"),
+		}
+		if v := cell("displayOrder"); v != nil {
+			n, err := strconv.Atoi(*v)
+			if err != nil {
+				return nil, fmt.Errorf("%w: line %d: displayOrder must be a whole number", domain.ErrBadParamInput, line)
+			}
+			rec.DisplayOrder = &n
+		}
+		if v := cell("isActive"); v != nil {
+			b, err := strconv.ParseBool(*v)
+			if err != nil {
+				return nil, fmt.Errorf("%w: line %d: isActive must be true or false", domain.ErrBadParamInput, line)
+			}
+			rec.IsActive = &b
+		}
+		records = append(records, rec)
+	}
+}
+
+func knownColumn(name string) bool {
+	for _, c := range csvColumns {
+		if c == name {
+			return true
+		}
+	}
+	return false
+}
+
+// badImportFile reports a file the parser gave up on, keeping its line
+// number, unless the body was cut off for being too large.
+func badImportFile(err error) error {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return err
+	}
+	return fmt.Errorf("%w: %v", domain.ErrBadParamInput, err)
+}
+
+func readJSONLRecords(body io.Reader) ([]domain.CategoryRecord, error) {
+	sc := bufio.NewScanner(body)
+	sc.Buffer(make([]byte, 0, 64<<10), 1<<20)
+
+	var records []domain.CategoryRecord
+	for line := 1; sc.Scan(); line++ {
+		if len(bytes.TrimSpace(sc.Bytes())) == 0 {
+			continue
+		}
+		if len(records) == domain.MaxImportRows {
+			return nil, fmt.Errorf("%w: line %d: at most %d records per import", domain.ErrBadParamInput, line, domain.MaxImportRows)
+		}
+
+		rec := domain.CategoryRecord{Line: line}
+		dec := json.NewDecoder(bytes.NewReader(sc.Bytes()))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&rec); err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", domain.ErrBadParamInput, line, err)
+		}
+		records = append(records, rec)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, badImportFile(err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("%w: the file is empty", domain.ErrBadParamInput)
+	}
+	return records, nil
+}
+
+// writeCSVRecords writes the header row at once, so that even an empty
+// export can be imported again.
+func writeCSVRecords(w io.Writer) func(domain.CategoryRecord) error {
+	cw := csv.NewWriter(w)
+	cw.Write(csvColumns)
+	cw.Flush()
+	return func(rec domain.CategoryRecord) error {
+		if err := cw.Write([]string{
+			rec.Path,
+			rec.Name,
+			optional(rec.Description),
+			optional(rec.ImageURL),
+			optional(rec.IconURL),
+			strconv.Itoa(*rec.DisplayOrder),
+			strconv.FormatBool(*rec.IsActive),
+		}); err != nil {
+			return err
+		}
+		cw.Flush()
+		return cw.Error()
+	}
+}
+
+func writeJSONLRecords(w io.Writer) func(domain.CategoryRecord) error {
+	enc := json.NewEncoder(w)
+	return func(rec domain.CategoryRecord) error {
+		return enc.Encode(rec)
+	}
+}
+
+func optional(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,114 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/tokobapak/catalog-service/internal/domain"
+)
+
+func TestReadCSVRecords(t *testing.T) {
+	body := "name,path,displayOrder,isActive\n" +
+		"Elektronik,elektronik,,\n" +
+		"\"Handphone, Tablet\",elektronik/handphone,2,false\n"
+
+	records, err := readCategoryRecords(strings.NewReader(body), "text/csv; charset=utf-8")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(records))
+	}
+	if r := records[0]; r.Line != 2 || r.Path != "elektronik" || r.DisplayOrder != nil || r.IsActive != nil || r.Description != nil {
+		t.Errorf("empty cells must stay unset, got %+v", r)
+	}
+	if r := records[1]; r.Line != 3 || r.Name != "Handphone, Tablet" || *r.DisplayOrder != 2 || *r.IsActive {
+		t.Errorf("unexpected record %+v", r)
+	}
+}
+
+func TestReadCategoryRecordsRejectsBadFiles(t *testing.T) {
+	cases := []struct {
+		name, contentType, body string
+		want                    error
+	}{
+		{"unknown column", CSVContentType, "path,name,colour\n", domain.ErrBadParamInput},
+		{"missing name", CSVContentType, "path\nelektronik\n", domain.ErrBadParamInput},
+		{"bad number", CSVContentType, "path,name,displayOrder\nelektronik,Elektronik,first\n", domain.ErrBadParamInput},
+		{"bad json", JSONLinesContentType, "{\"path\":\"elektronik\",\"name\":\"Elektronik\"}\n{\"path\":\n", domain.ErrBadParamInput},
+		{"unknown member", JSONLinesContentType, "{\"path\":\"elektronik\",\"id\":\"1\"}\n", domain.ErrBadParamInput},
+		{"empty", JSONLinesContentType, "\n\n", domain.ErrBadParamInput},
+		{"json array", "application/json", "[]", errUnsupportedImport},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := readCategoryRecords(strings.NewReader(tc.body), tc.contentType)
+			if !errors.Is(err, tc.want) {
+				t.Fatalf("expected %v, got %v", tc.want, err)
+			}
+		})
+	}
+}
+
+func TestReadJSONLRecordsCountsLines(t *testing.T) {
+	body := "{\"path\":\"elektronik\",\"name\":\"Elektronik\"}\n\n{\"path\":\"fashion\",\"name\":\"Fashion\",\"isActive\":false}\n"
+
+	records, err := readCategoryRecords(strings.NewReader(body), JSONLinesContentType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 || records[0].Line != 1 || records[1].Line != 3 || *records[1].IsActive {
+		t.Fatalf("unexpected records %+v", records)
+	}
+}
+
+// stubExporter exports a fixed list of records.
+type stubExporter struct {
+	domain.CategoryUsecase
+	records []domain.CategoryRecord
+}
+
+func (s stubExporter) Export(_ context.Context, fn func(domain.CategoryRecord) error) error {
+	for _, rec := range s.records {
+		if err := fn(rec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestExportEmptyCSVKeepsHeader(t *testing.T) {
+	h := &CategoryHandler{CUsecase: stubExporter{}}
+	w := httptest.NewRecorder()
+	h.Export(w, httptest.NewRequest("GET", "/api/v1/categories/export", nil))
+
+	if w.Code != 200 {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if got := w.Header().Get("Content-Type"); got != CSVContentType {
+		t.Errorf("expected %s, got %q", CSVContentType, got)
+	}
+	want := strings.Join(csvColumns, ",") + "\n"
+	if got := w.Body.String(); got != want {
+		t.Fatalf("expected only the header %q, got %q", want, got)
+	}
+
+	// The empty export must import again as an empty file, not a broken one.
+	records, err := readCategoryRecords(w.Body, CSVContentType)
+	if err != nil || len(records) != 0 {
+		t.Fatalf("expected no records, got %+v, %v", records, err)
+	}
+}
+
+func TestWriteCSVRecordsWritesHeaderAtOnce(t *testing.T) {
+	var buf bytes.Buffer
+	writeCSVRecords(&buf)
+
+	if want := strings.Join(csvColumns, ",") + "\n"; buf.String() != want {
+		t.Fatalf("expected the header %q before any record, got %q", want, buf.String())
+	}
+}
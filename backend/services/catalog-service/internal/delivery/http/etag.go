@@ -0,0 +1,87 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/tokobapak/catalog-service/internal/domain"
+)
+
+// errPreconditionRequired answers a write sent without If-Match.
+var errPreconditionRequired = errors.New("If-Match header is required; send the ETag from your last read")
+
+// etag is the entity tag of a category or brand at version, served in locale.
+// Each localized representation gets its own tag.
+func etag(version int64, locale string) string {
+	if locale == "" || locale == domain.DefaultLocale {
+		return fmt.Sprintf(`"%d"`, version)
+	}
+	return fmt.Sprintf(`"%d-%s"`, version, locale)
+}
+
+func setETag(w http.ResponseWriter, version int64, locale string) {
+	w.Header().Set("ETag", etag(version, locale))
+}
+
+// notModified reports whether the client's If-None-Match already names tag.
+// Comparison is weak, as RFC 9110 prescribes for If-None-Match.
+func notModified(r *http.Request, tag string) bool {
+	header := r.Header.Get("If-None-Match")
+	if header == "" {
+		return false
+	}
+
+	for _, t := range strings.Split(header, ",") {
+		t = strings.TrimSpace(t)
+		if t == "*" || strings.TrimPrefix(t, "W/") == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// ifMatchVersion reads the version a write is conditional on from If-Match.
+// The tag of any localized representation names its version; "*" matches
+// whatever version is current and yields 0.
+func ifMatchVersion(r *http.Request) (int64, error) {
+	header := strings.TrimSpace(r.Header.Get("If-Match"))
+	switch {
+	case header == "":
+		return 0, errPreconditionRequired
+	case header == "*":
+		return 0, nil
+	case strings.HasPrefix(header, "W/"):
+		// If-Match uses strong comparison, which a weak tag never passes.
+		return 0, fmt.Errorf("%w: weak entity tags never match", domain.ErrVersionMismatch)
+	}
+
+	tag, ok := strings.CutPrefix(header, `"`)
+	if ok {
+		tag, ok = strings.CutSuffix(tag, `"`)
+	}
+	if !ok {
+		return 0, fmt.Errorf("%w: malformed If-Match header", domain.ErrBadParamInput)
+	}
+
+	v, _, _ := strings.Cut(tag, "-")
+	version, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || version < 1 {
+		return 0, fmt.Errorf("%w: malformed If-Match header", domain.ErrBadParamInput)
+	}
+
+	return version, nil
+}
+
+// respondTagged answers a read of a single entity with its ETag, or with 304
+// Not Modified when the client already holds that representation.
+func respondTagged(w http.ResponseWriter, r *http.Request, tag string, v interface{}) {
+	w.Header().Set("ETag", tag)
+	if notModified(r, tag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	respondJSON(w, http.StatusOK, v)
+}
@@ -0,0 +1,59 @@
+package http
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/tokobapak/catalog-service/internal/domain"
+)
+
+func TestIfMatchVersion(t *testing.T) {
+	cases := []struct {
+		header  string
+		version int64
+		err     error
+	}{
+		{"", 0, errPreconditionRequired},
+		{"*", 0, nil},
+		{`"3"`, 3, nil},
+		{`"3-en-US"`, 3, nil},
+		{`W/"3"`, 0, domain.ErrVersionMismatch},
+		{`3`, 0, domain.ErrBadParamInput},
+		{`"0"`, 0, domain.ErrBadParamInput},
+		{`"abc"`, 0, domain.ErrBadParamInput},
+	}
+
+	for _, tc := range cases {
+		r := httptest.NewRequest("PUT", "/", nil)
+		if tc.header != "" {
+			r.Header.Set("If-Match", tc.header)
+		}
+
+		version, err := ifMatchVersion(r)
+		if version != tc.version || !errors.Is(err, tc.err) || (tc.err == nil && err != nil) {
+			t.Errorf("If-Match %q: got %d, %v; want %d, %v", tc.header, version, err, tc.version, tc.err)
+		}
+	}
+}
+
+func TestNotModified(t *testing.T) {
+	tag := etag(3, "en-US")
+	if tag != `"3-en-US"` || etag(3, domain.DefaultLocale) != `"3"` {
+		t.Fatalf("unexpected tags %s, %s", tag, etag(3, domain.DefaultLocale))
+	}
+
+	for header, want := range map[string]bool{
+		"":                     false,
+		`"3"`:                  false,
+		`"2-en-US", "3-en-US"`: true,
+		`W/"3-en-US"`:          true,
+		"*":                    true,
+	} {
+		r := httptest.NewRequest("GET", "/", nil)
+		r.Header.Set("If-None-Match", header)
+		if got := notModified(r, tag); got != want {
+			t.Errorf("If-None-Match %q: got %t, want %t", header, got, want)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/tokobapak/catalog-service/internal/domain"
+)
+
+type FeeRuleHandler struct {
+	FUsecase domain.FeeRuleUsecase
+}
+
+func NewFeeRuleHandler(r *chi.Mux, us domain.FeeRuleUsecase) {
+	handler := &FeeRuleHandler{
+		FUsecase: us,
+	}
+
+	r.Get("/api/v1/categories/{id}/fees", handler.GetEffective)
+	r.Get("/api/v1/categories/{id}/fee-rules", handler.Fetch)
+	r.Post("/api/v1/categories/{id}/fee-rules", handler.Store)
+	r.Put("/api/v1/categories/{id}/fee-rules/{ruleId}", handler.Update)
+	r.Delete("/api/v1/categories/{id}/fee-rules/{ruleId}", handler.Delete)
+}
+
+// GetEffective godoc
+// @Summary Effective category fees
+// @Description Get the commission and fixed fee charged on orders in a category at a point in time, taken from the nearest ancestor with a rule in force when the category has none. Rules are taken as of that time, ancestors as they are now. 404 when no rule applies.
+// @Tags fees
+// @Produce json
+// @Param id path string true "Category ID"
+// @Param at query string false "RFC 3339 time, such as the order time (default now)"
+// @Success 200 {object} domain.EffectiveFee
+// @Router /categories/{id}/fees [get]
+func (a *FeeRuleHandler) GetEffective(w http.ResponseWriter, r *http.Request) {
+	at := time.Now()
+	if v := r.URL.Query().Get("at"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			respondError(w, r, http.StatusBadRequest, "at must be an RFC 3339 time such as 2025-01-31T17:00:00+07:00")
+			return
+		}
+		at = t
+	}
+
+	fee, err := a.FUsecase.GetEffective(r.Context(), chi.URLParam(r, "id"), at)
+	if err != nil {
+		respondErr(w, r, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, fee)
+}
+
+// Fetch godoc
+// @Summary List fee rules
+// @Description Get the fee rules set on a category itself, oldest first
+// @Tags fees
+// @Produce json
+// @Param id path string true "Category ID"
+// @Success 200 {object} map[string]interface{}
+// @Router /categories/{id}/fee-rules [get]
+func (a *FeeRuleHandler) Fetch(w http.ResponseWriter, r *http.Request) {
+	list, err := a.FUsecase.FetchByCategory(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		respondErr(w, r, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"data": list,
+	})
+}
+
+// Store godoc
+// @Summary Create fee rule
+// @Description Set the fees of a category for a period. A period overlapping another rule of the category answers 409.
+// @Tags fees
+// @Accept json
+// @Produce json
+// @Param id path string true "Category ID"
+// @Param request body domain.FeeRule true "Fee rule"
+// @Success 201 {object} domain.FeeRule
+// @Router /categories/{id}/fee-rules [post]
+func (a *FeeRuleHandler) Store(w http.ResponseWriter, r *http.Request) {
+	var rule domain.FeeRule
+	if err := json.NewDecoder(r.Body).Decode(&rule); err != nil {
+		respondError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	rule.CategoryID = chi.URLParam(r, "id")
+	if err := a.FUsecase.Store(r.Context(), &rule); err != nil {
+		respondErr(w, r, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, rule)
+}
+
+// Update godoc
+// @Summary Update fee rule
+// @Description Replace a fee rule of a category. A period overlapping another rule of the category answers 409.
+// @Tags fees
+// @Accept json
+// @Produce json
+// @Param id path string true "Category ID"
+// @Param ruleId path string true "Fee rule ID"
+// @Param request body domain.FeeRule true "Fee rule"
+// @Success 200 {object} domain.FeeRule
+// @Router /categories/{id}/fee-rules/{ruleId} [put]
+func (a *FeeRuleHandler) Update(w http.ResponseWriter, r *http.Request) {
+	var rule domain.FeeRule
+	if err := json.NewDecoder(r.Body).Decode(&rule); err != nil {
+		respondError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	rule.ID = chi.URLParam(r, "ruleId")
+	rule.CategoryID = chi.URLParam(r, "id")
+	if err := a.FUsecase.Update(r.Context(), &rule); err != nil {
+		respondErr(w, r, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, rule)
+}
+
+func (a *FeeRuleHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	if err := a.FUsecase.Delete(r.Context(), chi.URLParam(r, "id"), chi.URLParam(r, "ruleId")); err != nil {
+		respondErr(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -9,22 +9,41 @@ import (
 	"github.com/tokobapak/catalog-service/internal/domain"
 )
 
+// rootParamID addresses the top level of the tree wherever a parent category ID is expected.
+const rootParamID = "root"
+
 type CategoryHandler struct {
 	CUsecase domain.CategoryUsecase
+	TUsecase domain.TranslationUsecase
 }
 
-func NewCategoryHandler(r *chi.Mux, us domain.CategoryUsecase) {
+func NewCategoryHandler(r *chi.Mux, us domain.CategoryUsecase, tu domain.TranslationUsecase) {
 	handler := &CategoryHandler{
 		CUsecase: us,
+		TUsecase: tu,
 	}
 
 	r.Route("/api/v1/categories", func(r chi.Router) {
 		r.Get("/", handler.Fetch)
+		r.Get("/tree", handler.GetTree)
+		r.Get("/export", handler.Export)
+		r.Post("/import", handler.Import)
+		r.Get("/slug/{slug}", handler.GetBySlug)
 		r.Get("/{id}", handler.GetByID)
+		r.Get("/{id}/breadcrumbs", handler.GetBreadcrumbs)
+		r.Get("/{id}/children", handler.GetChildren)
 		r.Post("/", handler.Store)
+		r.Post("/{id}/move", handler.Move)
+		r.Put("/{id}/order", handler.Reorder)
 		r.Put("/{id}", handler.Update)
+		r.Patch("/{id}", handler.Patch)
 		r.Delete("/{id}", handler.Delete)
+		r.Post("/{id}/restore", handler.Restore)
+		r.Post("/{id}/merge", handler.Merge)
+		r.Get("/{id}/history", handler.History)
+		r.Post("/{id}/history/{revisionId}/revert", handler.Revert)
 	})
+	r.Post("/api/v1/categories:batchGet", handler.BatchGet)
 }
 
 // Fetch godoc
@@ -32,83 +51,676 @@ func NewCategoryHandler(r *chi.Mux, us domain.CategoryUsecase) {
 // @Description Get all categories with cursor pagination
 // @Tags categories
 // @Produce json
-// @Param cursor query string false "Cursor for pagination"
-// @Param num query int false "Number of items to return"
+// @Param cursor query string false "Opaque cursor from nextCursor or prevCursor"
+// @Param num query int false "Number of items to return (default 10, max 100)"
+// @Param includeDeleted query bool false "Include soft-deleted categories (admin)"
+// @Param isActive query bool false "Only active or only inactive categories"
+// @Param includeScheduled query bool false "Include categories outside their visibility window (admin)"
+// @Param parentId query string false "Only children of this category, or root for top-level categories"
+// @Param q query string false "Search names and slugs by prefix, and names by similarity"
+// @Param sort query string false "Sort by name, displayOrder or createdAt (default)"
+// @Param order query string false "asc (default) or desc"
 // @Success 200 {object} map[string]interface{}
 // @Router /categories [get]
 func (a *CategoryHandler) Fetch(w http.ResponseWriter, r *http.Request) {
 	cursor := r.URL.Query().Get("cursor")
-	numS := r.URL.Query().Get("num")
-	num, _ := strconv.ParseInt(numS, 10, 64)
+	num, err := queryInt(r, "num")
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, domain.ErrBadParamInput.Error())
+		return
+	}
+
+	includeDeleted, err := queryBool(r, "includeDeleted")
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, domain.ErrBadParamInput.Error())
+		return
+	}
+
+	isActive, err := queryOptionalBool(r, "isActive")
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, domain.ErrBadParamInput.Error())
+		return
+	}
+
+	includeScheduled, err := queryBool(r, "includeScheduled")
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, domain.ErrBadParamInput.Error())
+		return
+	}
+
+	sort, err := querySort(r)
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, domain.ErrBadParamInput.Error())
+		return
+	}
+
+	filter := domain.CategoryFilter{
+		IncludeDeleted:   includeDeleted,
+		IsActive:         isActive,
+		IncludeScheduled: includeScheduled,
+		Query:            r.URL.Query().Get("q"),
+		Sort:             sort,
+	}
 
-	list, nextCursor, err := a.CUsecase.Fetch(r.Context(), cursor, num)
+	switch parentID := r.URL.Query().Get("parentId"); parentID {
+	case "":
+	case rootParamID:
+		filter.RootOnly = true
+	default:
+		filter.ParentID = &parentID
+	}
+	list, page, err := a.CUsecase.Fetch(r.Context(), filter, cursor, num)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, err.Error())
+		respondErr(w, r, err)
+		return
+	}
+
+	if err := a.localize(w, r, list); err != nil {
+		respondErr(w, r, err)
 		return
 	}
 
 	respondJSON(w, http.StatusOK, map[string]interface{}{
 		"data":       list,
-		"nextCursor": nextCursor,
+		"nextCursor": page.NextCursor,
+		"prevCursor": page.PrevCursor,
+		"hasMore":    page.HasMore,
+		"total":      page.Total,
 	})
 }
 
+// GetTree godoc
+// @Summary Category tree
+// @Description Get the nested category tree ordered by display order
+// @Tags categories
+// @Produce json
+// @Param depth query int false "Maximum depth to return, 0 for unlimited"
+// @Param activeOnly query bool false "Exclude inactive categories with their subtrees"
+// @Param includeScheduled query bool false "Include categories outside their visibility window, with their subtrees (admin)"
+// @Success 200 {object} map[string]interface{}
+// @Router /categories/tree [get]
+func (a *CategoryHandler) GetTree(w http.ResponseWriter, r *http.Request) {
+	depth := 0
+	if depthS := r.URL.Query().Get("depth"); depthS != "" {
+		d, err := strconv.Atoi(depthS)
+		if err != nil || d < 0 {
+			respondError(w, r, http.StatusBadRequest, domain.ErrBadParamInput.Error())
+			return
+		}
+		depth = d
+	}
+
+	activeOnly, err := queryBool(r, "activeOnly")
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, domain.ErrBadParamInput.Error())
+		return
+	}
+
+	includeScheduled, err := queryBool(r, "includeScheduled")
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, domain.ErrBadParamInput.Error())
+		return
+	}
+
+	tree, err := a.CUsecase.GetTree(r.Context(), depth, activeOnly, includeScheduled)
+	if err != nil {
+		respondErr(w, r, err)
+		return
+	}
+
+	if err := a.localize(w, r, tree); err != nil {
+		respondErr(w, r, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"data": tree,
+	})
+}
+
+// GetBySlug godoc
+// @Summary Get category by slug
+// @Description Get a category by its current slug or a former one. A former slug answers with a Link header pointing at the canonical URL.
+// @Tags categories
+// @Produce json
+// @Param slug path string true "Category slug"
+// @Param includeScheduled query bool false "Include categories outside their visibility window (admin)"
+// @Success 200 {object} domain.Category
+// @Router /categories/slug/{slug} [get]
+func (a *CategoryHandler) GetBySlug(w http.ResponseWriter, r *http.Request) {
+	includeScheduled, err := queryBool(r, "includeScheduled")
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, domain.ErrBadParamInput.Error())
+		return
+	}
+
+	category, moved, err := a.CUsecase.ResolveSlug(r.Context(), chi.URLParam(r, "slug"), includeScheduled)
+	if err != nil {
+		respondErr(w, r, err)
+		return
+	}
+
+	if moved {
+		setCanonicalLink(w, "/api/v1/categories/slug/"+category.Slug)
+	}
+
+	if err := a.localizeOne(w, r, &category); err != nil {
+		respondErr(w, r, err)
+		return
+	}
+	respondTagged(w, r, etag(category.Version, category.Locale), category)
+}
+
+// GetByID godoc
+// @Summary Get category
+// @Description Get a category by ID. The ETag changes with every write to the category or its translations; send it back in If-None-Match to get 304 when nothing changed.
+// @Tags categories
+// @Produce json
+// @Param id path string true "Category ID"
+// @Param If-None-Match header string false "ETag from a previous read"
+// @Param includeScheduled query bool false "Include categories outside their visibility window (admin)"
+// @Success 200 {object} domain.Category
+// @Success 304
+// @Router /categories/{id} [get]
 func (a *CategoryHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 	ctx := r.Context()
 
-	cat, err := a.CUsecase.GetByID(ctx, id)
+	includeScheduled, err := queryBool(r, "includeScheduled")
 	if err != nil {
-		respondError(w, http.StatusNotFound, domain.ErrNotFound.Error())
+		respondError(w, r, http.StatusBadRequest, domain.ErrBadParamInput.Error())
+		return
+	}
+
+	cat, err := a.CUsecase.GetByID(ctx, id, includeScheduled)
+	if err != nil {
+		respondErr(w, r, err)
+		return
+	}
+
+	if err := a.localizeOne(w, r, &cat); err != nil {
+		respondErr(w, r, err)
 		return
 	}
 
-	respondJSON(w, http.StatusOK, cat)
+	respondTagged(w, r, etag(cat.Version, cat.Locale), cat)
+}
+
+// batchGetRequest is the body of the :batchGet endpoints.
+type batchGetRequest struct {
+	IDs []string `json:"ids"`
+}
+
+// BatchGet godoc
+// @Summary Batch get categories
+// @Description Get up to 100 categories by ID in one call; unknown IDs are listed in missingIds
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Param request body batchGetRequest true "Category IDs"
+// @Success 200 {object} map[string]interface{}
+// @Router /categories:batchGet [post]
+func (a *CategoryHandler) BatchGet(w http.ResponseWriter, r *http.Request) {
+	var req batchGetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	list, missing, err := a.CUsecase.BatchGet(r.Context(), req.IDs)
+	if err != nil {
+		respondErr(w, r, err)
+		return
+	}
+
+	if err := a.localize(w, r, list); err != nil {
+		respondErr(w, r, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"data":       list,
+		"missingIds": missing,
+	})
+}
+
+// GetBreadcrumbs godoc
+// @Summary Category breadcrumbs
+// @Description Get the trail from the root category down to the given category
+// @Tags categories
+// @Produce json
+// @Param id path string true "Category ID"
+// @Param includeScheduled query bool false "Include categories outside their visibility window (admin)"
+// @Success 200 {object} map[string]interface{}
+// @Router /categories/{id}/breadcrumbs [get]
+func (a *CategoryHandler) GetBreadcrumbs(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	includeScheduled, err := queryBool(r, "includeScheduled")
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, domain.ErrBadParamInput.Error())
+		return
+	}
+
+	ancestors, err := a.CUsecase.GetAncestors(r.Context(), id, includeScheduled)
+	if err != nil {
+		respondErr(w, r, err)
+		return
+	}
+
+	if err := a.localize(w, r, ancestors); err != nil {
+		respondErr(w, r, err)
+		return
+	}
+
+	trail := make([]domain.Breadcrumb, 0, len(ancestors))
+	for _, c := range ancestors {
+		trail = append(trail, domain.Breadcrumb{ID: c.ID, Name: c.Name, Slug: c.Slug})
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"data": trail,
+	})
+}
+
+// GetChildren godoc
+// @Summary Child categories
+// @Description Get the direct children of a category, or its descendants down to depth levels below it
+// @Tags categories
+// @Produce json
+// @Param id path string true "Category ID"
+// @Param depth query int false "Levels below the category to return, 0 for unlimited (default 1)"
+// @Param recursive query bool false "Return all descendants, as depth=0 does"
+// @Param includeScheduled query bool false "Include categories outside their visibility window (admin)"
+// @Success 200 {object} map[string]interface{}
+// @Router /categories/{id}/children [get]
+func (a *CategoryHandler) GetChildren(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	recursive, err := queryBool(r, "recursive")
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, domain.ErrBadParamInput.Error())
+		return
+	}
+
+	depth := 1
+	if recursive {
+		depth = 0
+	}
+	if depthS := r.URL.Query().Get("depth"); depthS != "" {
+		d, err := strconv.Atoi(depthS)
+		if err != nil || d < 0 {
+			respondError(w, r, http.StatusBadRequest, domain.ErrBadParamInput.Error())
+			return
+		}
+		depth = d
+	}
+
+	includeScheduled, err := queryBool(r, "includeScheduled")
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, domain.ErrBadParamInput.Error())
+		return
+	}
+
+	var list []domain.Category
+	if depth == 1 {
+		list, err = a.CUsecase.GetChildren(r.Context(), id, includeScheduled)
+	} else {
+		list, err = a.CUsecase.GetDescendants(r.Context(), id, depth, includeScheduled)
+	}
+	if err != nil {
+		respondErr(w, r, err)
+		return
+	}
+
+	if err := a.localize(w, r, list); err != nil {
+		respondErr(w, r, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"data": list,
+	})
+}
+
+// localize translates list for the client's Accept-Language and describes
+// the result in the response headers.
+func (a *CategoryHandler) localize(w http.ResponseWriter, r *http.Request, list []domain.Category) error {
+	if err := a.TUsecase.LocalizeCategories(r.Context(), r.Header.Get("Accept-Language"), list); err != nil {
+		return err
+	}
+
+	var locales []string
+	var collect func([]domain.Category)
+	collect = func(list []domain.Category) {
+		for _, c := range list {
+			locales = append(locales, c.Locale)
+			collect(c.Children)
+		}
+	}
+	collect(list)
+
+	setContentLanguage(w, locales)
+	return nil
+}
+
+func (a *CategoryHandler) localizeOne(w http.ResponseWriter, r *http.Request, c *domain.Category) error {
+	list := []domain.Category{*c}
+	if err := a.localize(w, r, list); err != nil {
+		return err
+	}
+	*c = list[0]
+	return nil
+}
+
+type moveCategoryRequest struct {
+	ParentID *string `json:"parentId"`
+	Position *int    `json:"position"`
+}
+
+// Move godoc
+// @Summary Move category
+// @Description Reparent a category, rejecting cycles and trees deeper than the maximum depth
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Param id path string true "Category ID"
+// @Param request body moveCategoryRequest true "New parent (null for root) and optional position among its children"
+// @Success 200 {object} domain.Category
+// @Router /categories/{id}/move [post]
+func (a *CategoryHandler) Move(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	var req moveCategoryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	position := -1
+	if req.Position != nil {
+		if *req.Position < 0 {
+			respondError(w, r, http.StatusBadRequest, domain.ErrBadParamInput.Error())
+			return
+		}
+		position = *req.Position
+	}
+
+	category, err := a.CUsecase.Move(r.Context(), id, req.ParentID, position)
+	if err != nil {
+		respondErr(w, r, err)
+		return
+	}
+
+	setETag(w, category.Version, "")
+	respondJSON(w, http.StatusOK, category)
+}
+
+type reorderCategoriesRequest struct {
+	IDs []string `json:"ids"`
+}
+
+// Reorder godoc
+// @Summary Reorder child categories
+// @Description Rewrite the display order of a category's children; the list must contain exactly the current children
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Param id path string true "Parent category ID, or root for top-level categories"
+// @Param request body reorderCategoriesRequest true "Child IDs in their new order"
+// @Success 200 {object} map[string]interface{}
+// @Router /categories/{id}/order [put]
+func (a *CategoryHandler) Reorder(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	var req reorderCategoriesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var parentID *string
+	if id != rootParamID {
+		parentID = &id
+	}
+
+	list, err := a.CUsecase.Reorder(r.Context(), parentID, req.IDs)
+	if err != nil {
+		respondErr(w, r, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"data": list,
+	})
 }
 
 func (a *CategoryHandler) Store(w http.ResponseWriter, r *http.Request) {
 	var category domain.Category
 	if err := json.NewDecoder(r.Body).Decode(&category); err != nil {
-		respondError(w, http.StatusBadRequest, err.Error())
+		respondError(w, r, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	if err := a.CUsecase.Store(r.Context(), &category); err != nil {
-		respondError(w, http.StatusInternalServerError, err.Error())
+		respondErr(w, r, err)
 		return
 	}
 
+	setETag(w, category.Version, "")
 	respondJSON(w, http.StatusCreated, category)
 }
 
+// Update godoc
+// @Summary Update category
+// @Description Replace a category. If-Match must carry the ETag of the version being edited; a category changed since answers 412.
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Param id path string true "Category ID"
+// @Param If-Match header string true "ETag from the last read, or * to overwrite unconditionally"
+// @Param request body domain.Category true "Category"
+// @Success 200 {object} domain.Category
+// @Router /categories/{id} [put]
 func (a *CategoryHandler) Update(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		respondErr(w, r, err)
+		return
+	}
+
 	var category domain.Category
 	if err := json.NewDecoder(r.Body).Decode(&category); err != nil {
-		respondError(w, http.StatusBadRequest, err.Error())
+		respondError(w, r, http.StatusBadRequest, err.Error())
 		return
 	}
-	
+
 	category.ID = id
+	category.Version = version
 	if err := a.CUsecase.Update(r.Context(), &category); err != nil {
-		respondError(w, http.StatusInternalServerError, err.Error())
+		respondErr(w, r, err)
 		return
 	}
 
+	setETag(w, category.Version, "")
 	respondJSON(w, http.StatusOK, category)
 }
 
+// Patch godoc
+// @Summary Patch category
+// @Description Change some fields of a category with a JSON merge patch (RFC 7396): absent members are left alone and null clears parentId, imageUrl and iconUrl. If-Match must carry the ETag of the version being edited; a category changed since answers 412.
+// @Tags categories
+// @Accept application/merge-patch+json
+// @Produce json
+// @Param id path string true "Category ID"
+// @Param If-Match header string true "ETag from the last read, or * to patch unconditionally"
+// @Param request body domain.CategoryPatch true "Merge patch"
+// @Success 200 {object} domain.Category
+// @Router /categories/{id} [patch]
+func (a *CategoryHandler) Patch(w http.ResponseWriter, r *http.Request) {
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		respondErr(w, r, err)
+		return
+	}
+
+	var patch domain.CategoryPatch
+	if err := decodeMergePatch(r, &patch); err != nil {
+		respondErr(w, r, err)
+		return
+	}
+
+	category, err := a.CUsecase.Patch(r.Context(), chi.URLParam(r, "id"), version, patch)
+	if err != nil {
+		respondErr(w, r, err)
+		return
+	}
+
+	setETag(w, category.Version, "")
+	respondJSON(w, http.StatusOK, category)
+}
+
+// Delete godoc
+// @Summary Delete category
+// @Description Soft-delete a category; policy decides what happens to its children. If-Match must carry the ETag of the version being deleted; a category changed since answers 412.
+// @Tags categories
+// @Param id path string true "Category ID"
+// @Param If-Match header string true "ETag from the last read, or * to delete unconditionally"
+// @Param policy query string false "block (default), cascade or reparent"
+// @Success 204
+// @Router /categories/{id} [delete]
 func (a *CategoryHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
-	
-	if err := a.CUsecase.Delete(r.Context(), id); err != nil {
-		respondError(w, http.StatusInternalServerError, err.Error())
+	policy := domain.DeletePolicy(r.URL.Query().Get("policy"))
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		respondErr(w, r, err)
+		return
+	}
+
+	if err := a.CUsecase.Delete(r.Context(), id, version, policy); err != nil {
+		respondErr(w, r, err)
 		return
 	}
 
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// Restore godoc
+// @Summary Restore category
+// @Description Undelete a soft-deleted category together with the descendants archived with it
+// @Tags categories
+// @Produce json
+// @Param id path string true "Category ID"
+// @Success 200 {object} domain.Category
+// @Router /categories/{id}/restore [post]
+func (a *CategoryHandler) Restore(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	category, err := a.CUsecase.Restore(r.Context(), id)
+	if err != nil {
+		respondErr(w, r, err)
+		return
+	}
+
+	setETag(w, category.Version, "")
+	respondJSON(w, http.StatusOK, category)
+}
+
+type mergeRequest struct {
+	Into string `json:"into"`
+}
+
+// Merge godoc
+// @Summary Merge category
+// @Description Fold a duplicate category into another one, which takes over its children, attribute, brand and taxonomy links and its slug. The duplicate is soft-deleted with mergedInto set. If-Match must carry the ETag of the duplicate.
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of the duplicate category"
+// @Param If-Match header string true "ETag of the duplicate from the last read, or * to merge unconditionally"
+// @Param request body mergeRequest true "ID of the surviving category"
+// @Success 200 {object} domain.Category
+// @Router /categories/{id}/merge [post]
+func (a *CategoryHandler) Merge(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		respondErr(w, r, err)
+		return
+	}
+
+	var req mergeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	if req.Into == "" {
+		respondError(w, r, http.StatusBadRequest, "into must name the surviving category")
+		return
+	}
+
+	category, err := a.CUsecase.Merge(r.Context(), id, req.Into, version)
+	if err != nil {
+		respondErr(w, r, err)
+		return
+	}
+
+	setETag(w, category.Version, "")
+	respondJSON(w, http.StatusOK, category)
+}
+
+// History godoc
+// @Summary Category revision history
+// @Description List the recorded changes of a category, newest first, with the actor and a field-by-field diff
+// @Tags categories
+// @Produce json
+// @Param id path string true "Category ID"
+// @Param cursor query string false "Opaque cursor from nextCursor or prevCursor"
+// @Param num query int false "Number of items to return (default 10, max 100)"
+// @Success 200 {object} map[string]interface{}
+// @Router /categories/{id}/history [get]
+func (a *CategoryHandler) History(w http.ResponseWriter, r *http.Request) {
+	num, err := queryInt(r, "num")
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, domain.ErrBadParamInput.Error())
+		return
+	}
+
+	list, page, err := a.CUsecase.History(r.Context(), chi.URLParam(r, "id"), r.URL.Query().Get("cursor"), num)
+	if err != nil {
+		respondErr(w, r, err)
+		return
+	}
+
+	respondRevisions(w, list, page)
+}
+
+// Revert godoc
+// @Summary Revert category
+// @Description Restore the fields of a category to how a revision left them; its position among its siblings is kept
+// @Tags categories
+// @Produce json
+// @Param id path string true "Category ID"
+// @Param revisionId path int true "Revision ID"
+// @Success 200 {object} domain.Category
+// @Router /categories/{id}/history/{revisionId}/revert [post]
+func (a *CategoryHandler) Revert(w http.ResponseWriter, r *http.Request) {
+	revisionID, err := revisionIDParam(r)
+	if err != nil {
+		respondError(w, r, http.StatusBadRequest, domain.ErrBadParamInput.Error())
+		return
+	}
+
+	category, err := a.CUsecase.Revert(r.Context(), chi.URLParam(r, "id"), revisionID)
+	if err != nil {
+		respondErr(w, r, err)
+		return
+	}
+
+	setETag(w, category.Version, "")
+	respondJSON(w, http.StatusOK, category)
+}
+
 func respondJSON(w http.ResponseWriter, status int, payload interface{}) {
 	response, _ := json.Marshal(payload)
 	w.Header().Set("Content-Type", "application/json")
@@ -116,6 +728,67 @@ func respondJSON(w http.ResponseWriter, status int, payload interface{}) {
 	w.Write(response)
 }
 
-func respondError(w http.ResponseWriter, code int, message string) {
-	respondJSON(w, code, map[string]string{"error": message})
+// setCanonicalLink tells the client that the resource it asked for lives at
+// path now, while still answering the request.
+func setCanonicalLink(w http.ResponseWriter, path string) {
+	w.Header().Set("Link", "<"+path+`>; rel="canonical"`)
+}
+
+// queryBool parses an optional boolean query parameter, defaulting to false.
+func queryBool(r *http.Request, key string) (bool, error) {
+	v := r.URL.Query().Get(key)
+	if v == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(v)
+}
+
+// queryOptionalBool parses a boolean query parameter, returning nil when it is absent.
+func queryOptionalBool(r *http.Request, key string) (*bool, error) {
+	v := r.URL.Query().Get(key)
+	if v == "" {
+		return nil, nil
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+// querySort reads the sort and order query parameters. Whether the field can
+// be sorted on is left to the repository.
+func querySort(r *http.Request) (domain.Sort, error) {
+	sort := domain.Sort{Field: domain.SortField(r.URL.Query().Get("sort"))}
+	switch r.URL.Query().Get("order") {
+	case "", "asc":
+	case "desc":
+		sort.Desc = true
+	default:
+		return domain.Sort{}, domain.ErrBadParamInput
+	}
+	return sort, nil
+}
+
+// queryInt parses an optional integer query parameter, defaulting to 0.
+func queryInt(r *http.Request, key string) (int64, error) {
+	v := r.URL.Query().Get(key)
+	if v == "" {
+		return 0, nil
+	}
+	return strconv.ParseInt(v, 10, 64)
+}
+
+func revisionIDParam(r *http.Request) (int64, error) {
+	return strconv.ParseInt(chi.URLParam(r, "revisionId"), 10, 64)
+}
+
+func respondRevisions(w http.ResponseWriter, list []domain.Revision, page domain.PageInfo) {
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"data":       list,
+		"nextCursor": page.NextCursor,
+		"prevCursor": page.PrevCursor,
+		"hasMore":    page.HasMore,
+		"total":      page.Total,
+	})
 }
@@ -0,0 +1,231 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/tokobapak/catalog-service/internal/domain"
+)
+
+// stubCategoryTree answers tree reads for elektronik > handphone > android
+// and records the depth descendants were asked for.
+type stubCategoryTree struct {
+	domain.CategoryUsecase
+	depths []int
+}
+
+func (s *stubCategoryTree) GetAncestors(_ context.Context, id string, _ bool) ([]domain.Category, error) {
+	if id != "android" {
+		return nil, domain.ErrNotFound
+	}
+	return []domain.Category{
+		{ID: "elektronik", Name: "Elektronik", Slug: "elektronik"},
+		{ID: "handphone", Name: "Handphone", Slug: "handphone"},
+		{ID: "android", Name: "Android", Slug: "android"},
+	}, nil
+}
+
+func (s *stubCategoryTree) GetChildren(context.Context, string, bool) ([]domain.Category, error) {
+	s.depths = append(s.depths, 1)
+	return []domain.Category{{ID: "handphone"}}, nil
+}
+
+func (s *stubCategoryTree) GetDescendants(_ context.Context, _ string, maxDepth int, _ bool) ([]domain.Category, error) {
+	s.depths = append(s.depths, maxDepth)
+	return []domain.Category{{ID: "handphone"}, {ID: "android"}}, nil
+}
+
+// stubLocalizer leaves every category untranslated.
+type stubLocalizer struct {
+	domain.TranslationUsecase
+}
+
+func (stubLocalizer) LocalizeCategories(context.Context, string, []domain.Category) error {
+	return nil
+}
+
+// serve routes a request to the category handler backed by cu.
+func serve(cu domain.CategoryUsecase, method, target, body string) *httptest.ResponseRecorder {
+	return serveRequest(cu, httptest.NewRequest(method, target, strings.NewReader(body)))
+}
+
+func serveRequest(cu domain.CategoryUsecase, req *http.Request) *httptest.ResponseRecorder {
+	r := chi.NewRouter()
+	NewCategoryHandler(r, cu, stubLocalizer{})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestGetBreadcrumbs(t *testing.T) {
+	w := serve(&stubCategoryTree{}, "GET", "/api/v1/categories/android/breadcrumbs", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+
+	var res struct {
+		Data []domain.Breadcrumb `json:"data"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&res); err != nil {
+		t.Fatal(err)
+	}
+	var slugs []string
+	for _, b := range res.Data {
+		slugs = append(slugs, b.Slug)
+	}
+	if got := strings.Join(slugs, "/"); got != "elektronik/handphone/android" {
+		t.Fatalf("expected the trail from the root, got %s", got)
+	}
+
+	if w := serve(&stubCategoryTree{}, "GET", "/api/v1/categories/missing/breadcrumbs", ""); w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for an unknown category, got %d", w.Code)
+	}
+	if w := serve(&stubCategoryTree{}, "GET", "/api/v1/categories/android/breadcrumbs?includeScheduled=maybe", ""); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for a bad includeScheduled, got %d", w.Code)
+	}
+}
+
+func TestGetChildrenDepth(t *testing.T) {
+	cases := []struct {
+		query string
+		code  int
+		depth int
+	}{
+		{"", http.StatusOK, 1},
+		{"?depth=1", http.StatusOK, 1},
+		{"?depth=2", http.StatusOK, 2},
+		{"?depth=0", http.StatusOK, 0},
+		{"?recursive=true", http.StatusOK, 0},
+		{"?recursive=true&depth=2", http.StatusOK, 2},
+		{"?depth=-1", http.StatusBadRequest, 0},
+		{"?depth=all", http.StatusBadRequest, 0},
+	}
+	for _, tc := range cases {
+		t.Run(tc.query, func(t *testing.T) {
+			cu := &stubCategoryTree{}
+			w := serve(cu, "GET", "/api/v1/categories/elektronik/children"+tc.query, "")
+			if w.Code != tc.code {
+				t.Fatalf("expected %d, got %d: %s", tc.code, w.Code, w.Body)
+			}
+			if tc.code != http.StatusOK {
+				if len(cu.depths) != 0 {
+					t.Fatalf("expected no read, got depths %v", cu.depths)
+				}
+				return
+			}
+			if len(cu.depths) != 1 || cu.depths[0] != tc.depth {
+				t.Fatalf("expected a read to depth %d, got %v", tc.depth, cu.depths)
+			}
+		})
+	}
+}
+
+// stubReorder records the parents it reorders under and fails with err.
+type stubReorder struct {
+	domain.CategoryUsecase
+	parents []*string
+	err     error
+}
+
+func (s *stubReorder) Reorder(_ context.Context, parentID *string, _ []string) ([]domain.Category, error) {
+	s.parents = append(s.parents, parentID)
+	return nil, s.err
+}
+
+func TestReorder(t *testing.T) {
+	cases := []struct {
+		name, target, body string
+		err                error
+		code               int
+		parentID           string
+	}{
+		{"children", "/api/v1/categories/elektronik/order", `{"ids":["laptop","handphone"]}`, nil, http.StatusOK, "elektronik"},
+		{"top level", "/api/v1/categories/root/order", `{"ids":["fashion","elektronik"]}`, nil, http.StatusOK, ""},
+		{"stale list", "/api/v1/categories/elektronik/order", `{"ids":["laptop"]}`, domain.ErrOrderMismatch, http.StatusConflict, "elektronik"},
+		{"bad body", "/api/v1/categories/elektronik/order", `{"ids":`, nil, http.StatusBadRequest, ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cu := &stubReorder{err: tc.err}
+			w := serve(cu, "PUT", tc.target, tc.body)
+			if w.Code != tc.code {
+				t.Fatalf("expected %d, got %d: %s", tc.code, w.Code, w.Body)
+			}
+			if tc.code == http.StatusBadRequest {
+				return
+			}
+			if len(cu.parents) != 1 {
+				t.Fatalf("expected one reorder, got %d", len(cu.parents))
+			}
+			if got := cu.parents[0]; (got == nil) != (tc.parentID == "") || (got != nil && *got != tc.parentID) {
+				t.Fatalf("expected parent %q, got %v", tc.parentID, got)
+			}
+		})
+	}
+}
+
+// stubDelete records the delete policies it is given and fails deletes and
+// restores with err.
+type stubDelete struct {
+	domain.CategoryUsecase
+	policies []domain.DeletePolicy
+	err      error
+}
+
+func (s *stubDelete) Delete(_ context.Context, _ string, _ int64, policy domain.DeletePolicy) error {
+	s.policies = append(s.policies, policy)
+	return s.err
+}
+
+func (s *stubDelete) Restore(_ context.Context, id string) (domain.Category, error) {
+	if s.err != nil {
+		return domain.Category{}, s.err
+	}
+	return domain.Category{ID: id, Version: 3}, nil
+}
+
+func TestDelete(t *testing.T) {
+	cases := []struct {
+		name, query string
+		err         error
+		code        int
+	}{
+		{"block", "", nil, http.StatusNoContent},
+		{"has children", "?policy=block", domain.ErrCategoryHasChildren, http.StatusConflict},
+		{"cascade", "?policy=cascade", nil, http.StatusNoContent},
+		{"unknown policy", "?policy=purge", fmt.Errorf("%w: unknown delete policy", domain.ErrBadParamInput), http.StatusBadRequest},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cu := &stubDelete{err: tc.err}
+			r := httptest.NewRequest("DELETE", "/api/v1/categories/handphone"+tc.query, nil)
+			r.Header.Set("If-Match", "*")
+			w := serveRequest(cu, r)
+			if w.Code != tc.code {
+				t.Fatalf("expected %d, got %d: %s", tc.code, w.Code, w.Body)
+			}
+			if want := domain.DeletePolicy(r.URL.Query().Get("policy")); len(cu.policies) != 1 || cu.policies[0] != want {
+				t.Fatalf("expected the policy %q passed on, got %v", want, cu.policies)
+			}
+		})
+	}
+}
+
+func TestRestore(t *testing.T) {
+	w := serve(&stubDelete{}, "POST", "/api/v1/categories/handphone/restore", "")
+	if w.Code != http.StatusOK || w.Header().Get("ETag") == "" {
+		t.Fatalf("expected 200 with an ETag, got %d %v", w.Code, w.Header())
+	}
+
+	merged := fmt.Errorf("%w: category was merged into %q", domain.ErrConflict, "hp")
+	if w := serve(&stubDelete{err: merged}, "POST", "/api/v1/categories/handphone/restore", ""); w.Code != http.StatusConflict {
+		t.Fatalf("expected 409 for a merged category, got %d", w.Code)
+	}
+}
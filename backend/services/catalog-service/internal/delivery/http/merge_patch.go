@@ -0,0 +1,34 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+
+	"github.com/tokobapak/catalog-service/internal/domain"
+)
+
+// MergePatchContentType is the media type of a JSON merge patch (RFC 7396).
+const MergePatchContentType = "application/merge-patch+json"
+
+// errUnsupportedPatch answers a PATCH whose body is not a merge patch.
+var errUnsupportedPatch = errors.New("PATCH bodies must be " + MergePatchContentType)
+
+// decodeMergePatch reads a merge patch into v. Plain application/json is
+// accepted as well. Members v does not know, including read-only ones such as
+// id or version, are rejected rather than silently ignored.
+func decodeMergePatch(r *http.Request, v interface{}) error {
+	mt, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || (mt != MergePatchContentType && mt != "application/json") {
+		return errUnsupportedPatch
+	}
+
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrBadParamInput, err)
+	}
+	return nil
+}
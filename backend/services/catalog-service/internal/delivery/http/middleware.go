@@ -0,0 +1,22 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/tokobapak/catalog-service/internal/domain"
+)
+
+// ActorHeader carries the ID of the user behind a request, as set by the API gateway.
+const ActorHeader = "X-User-ID"
+
+// Actor records the user named in ActorHeader on the request context so the
+// revisions written while serving it name their author.
+func Actor(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actor := r.Header.Get(ActorHeader)
+		if actor == "" {
+			actor = domain.AnonymousActor
+		}
+		next.ServeHTTP(w, r.WithContext(domain.WithActor(r.Context(), actor)))
+	})
+}
@@ -0,0 +1,104 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+
+	"github.com/tokobapak/catalog-service/internal/domain"
+	"github.com/tokobapak/catalog-service/pkg/validator"
+)
+
+// ProblemContentType is the media type of error responses (RFC 7807).
+const ProblemContentType = "application/problem+json"
+
+// Problem is the body of every error response. Type is always about:blank,
+// so Title is the standard text of Status; Detail says what went wrong with
+// this request.
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	// RequestID matches the X-Request-Id of the request in the access log.
+	RequestID string `json:"requestId,omitempty"`
+	// Errors lists the offending fields when the input failed validation.
+	Errors validator.Errors `json:"errors,omitempty"`
+}
+
+func newProblem(r *http.Request, status int, detail string) Problem {
+	return Problem{
+		Type:      "about:blank",
+		Title:     http.StatusText(status),
+		Status:    status,
+		Detail:    detail,
+		Instance:  r.URL.Path,
+		RequestID: middleware.GetReqID(r.Context()),
+	}
+}
+
+func writeProblem(w http.ResponseWriter, p Problem) {
+	body, _ := json.Marshal(p)
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(p.Status)
+	w.Write(body)
+}
+
+// respondError answers with a problem of the given status and detail.
+func respondError(w http.ResponseWriter, r *http.Request, code int, message string) {
+	writeProblem(w, newProblem(r, code, message))
+}
+
+// respondErr answers with the problem err translates to. Internal errors are
+// logged under the request ID and their details kept from the client.
+func respondErr(w http.ResponseWriter, r *http.Request, err error) {
+	status := getStatusCode(err)
+	detail := err.Error()
+	if status == http.StatusInternalServerError {
+		log.Printf("[%s] %s %s: %v", middleware.GetReqID(r.Context()), r.Method, r.URL.Path, err)
+		detail = domain.ErrInternalServerError.Error()
+	}
+
+	p := newProblem(r, status, detail)
+	errors.As(err, &p.Errors)
+	writeProblem(w, p)
+}
+
+// NotFound answers requests for routes that do not exist.
+func NotFound(w http.ResponseWriter, r *http.Request) {
+	respondError(w, r, http.StatusNotFound, "no route for "+r.URL.Path)
+}
+
+// MethodNotAllowed answers requests with a method the route does not serve.
+func MethodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	respondError(w, r, http.StatusMethodNotAllowed, r.Method+" is not allowed on "+r.URL.Path)
+}
+
+// getStatusCode translates an error from the usecases into a response status.
+func getStatusCode(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrConflict), errors.Is(err, domain.ErrOrderMismatch), errors.Is(err, domain.ErrCategoryHasChildren):
+		return http.StatusConflict
+	case errors.Is(err, domain.ErrBadParamInput):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrValidation), errors.Is(err, domain.ErrCategoryCycle), errors.Is(err, domain.ErrMaxDepthExceeded):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, domain.ErrVersionMismatch):
+		return http.StatusPreconditionFailed
+	case errors.Is(err, errPreconditionRequired):
+		return http.StatusPreconditionRequired
+	case errors.Is(err, errUnsupportedPatch), errors.Is(err, errUnsupportedImport):
+		return http.StatusUnsupportedMediaType
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
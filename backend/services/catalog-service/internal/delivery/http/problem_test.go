@@ -0,0 +1,77 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5/middleware"
+
+	"github.com/tokobapak/catalog-service/internal/domain"
+	"github.com/tokobapak/catalog-service/pkg/validator"
+)
+
+func decodeProblem(t *testing.T, w *httptest.ResponseRecorder) Problem {
+	t.Helper()
+	if ct := w.Header().Get("Content-Type"); ct != ProblemContentType {
+		t.Fatalf("expected %s, got %s", ProblemContentType, ct)
+	}
+	var p Problem
+	if err := json.NewDecoder(w.Body).Decode(&p); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestRespondErrListsFieldErrors(t *testing.T) {
+	var v validator.Validator
+	v.Add("name", validator.CodeRequired, "must not be empty")
+	v.Add("parentId", validator.CodeNotFound, `category "x" does not exist`)
+
+	r := httptest.NewRequest(http.MethodPost, "/api/v1/categories", nil)
+	r = r.WithContext(context.WithValue(r.Context(), middleware.RequestIDKey, "req-1"))
+	w := httptest.NewRecorder()
+	respondErr(w, r, fmt.Errorf("%w: %w", domain.ErrValidation, v.Err()))
+
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("expected 422, got %d", w.Code)
+	}
+	p := decodeProblem(t, w)
+	if p.Status != 422 || p.Title != "Unprocessable Entity" || p.Instance != "/api/v1/categories" || p.RequestID != "req-1" {
+		t.Fatalf("unexpected problem %+v", p)
+	}
+	if len(p.Errors) != 2 || p.Errors[1].Field != "parentId" || p.Errors[1].Code != validator.CodeNotFound {
+		t.Fatalf("unexpected field errors %+v", p.Errors)
+	}
+}
+
+func TestRespondErrHidesInternalErrors(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/api/v1/brands/1", nil)
+	w := httptest.NewRecorder()
+	respondErr(w, r, fmt.Errorf("dial tcp 10.0.0.5:5432: connection refused"))
+
+	p := decodeProblem(t, w)
+	if p.Status != http.StatusInternalServerError || strings.Contains(p.Detail, "10.0.0.5") {
+		t.Fatalf("expected an opaque 500, got %+v", p)
+	}
+}
+
+func TestGetStatusCode(t *testing.T) {
+	cases := map[error]int{
+		fmt.Errorf("%w: slug taken", domain.ErrConflict): http.StatusConflict,
+		domain.ErrNotFound:                                http.StatusNotFound,
+		domain.ErrBadParamInput:                           http.StatusBadRequest,
+		domain.ErrValidation:                              http.StatusUnprocessableEntity,
+		fmt.Errorf("query: %w", context.DeadlineExceeded): http.StatusGatewayTimeout,
+		fmt.Errorf("db down"):                             http.StatusInternalServerError,
+	}
+	for err, want := range cases {
+		if got := getStatusCode(err); got != want {
+			t.Errorf("%v: expected %d, got %d", err, want, got)
+		}
+	}
+}
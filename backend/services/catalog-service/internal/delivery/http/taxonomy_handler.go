@@ -0,0 +1,143 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/tokobapak/catalog-service/internal/domain"
+)
+
+type TaxonomyHandler struct {
+	TUsecase domain.TaxonomyUsecase
+}
+
+func NewTaxonomyHandler(r *chi.Mux, us domain.TaxonomyUsecase) {
+	handler := &TaxonomyHandler{
+		TUsecase: us,
+	}
+
+	r.Get("/api/v1/taxonomies", handler.Fetch)
+	r.Get("/api/v1/taxonomies/{taxonomy}/unmapped", handler.Unmapped)
+
+	r.Get("/api/v1/categories/{id}/taxonomies", handler.GetEffective)
+	r.Get("/api/v1/categories/{id}/taxonomies/{taxonomy}", handler.Resolve)
+	r.Put("/api/v1/categories/{id}/taxonomies/{taxonomy}", handler.Put)
+	r.Delete("/api/v1/categories/{id}/taxonomies/{taxonomy}", handler.Delete)
+}
+
+// Fetch godoc
+// @Summary List taxonomies
+// @Description Get the external taxonomies loaded from the taxonomy directory
+// @Tags taxonomies
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Router /taxonomies [get]
+func (a *TaxonomyHandler) Fetch(w http.ResponseWriter, r *http.Request) {
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"data": a.TUsecase.Taxonomies(r.Context()),
+	})
+}
+
+// Unmapped godoc
+// @Summary Unmapped categories
+// @Description Get the leaf categories that neither they nor any ancestor map to a code in the taxonomy, or that map to a code the taxonomy file no longer has
+// @Tags taxonomies
+// @Produce json
+// @Param taxonomy path string true "Taxonomy name"
+// @Success 200 {object} map[string]interface{}
+// @Router /taxonomies/{taxonomy}/unmapped [get]
+func (a *TaxonomyHandler) Unmapped(w http.ResponseWriter, r *http.Request) {
+	list, err := a.TUsecase.Unmapped(r.Context(), chi.URLParam(r, "taxonomy"))
+	if err != nil {
+		respondErr(w, r, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"data": list,
+	})
+}
+
+// GetEffective godoc
+// @Summary Effective taxonomy codes
+// @Description Get the external code that applies to a category in each taxonomy, including codes inherited from its ancestors
+// @Tags taxonomies
+// @Produce json
+// @Param id path string true "Category ID"
+// @Success 200 {object} map[string]interface{}
+// @Router /categories/{id}/taxonomies [get]
+func (a *TaxonomyHandler) GetEffective(w http.ResponseWriter, r *http.Request) {
+	list, err := a.TUsecase.GetEffective(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		respondErr(w, r, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{
+		"data": list,
+	})
+}
+
+// Resolve godoc
+// @Summary Resolve taxonomy code
+// @Description Get the external code that applies to a category in one taxonomy; 404 when neither the category nor an ancestor is mapped
+// @Tags taxonomies
+// @Produce json
+// @Param id path string true "Category ID"
+// @Param taxonomy path string true "Taxonomy name"
+// @Success 200 {object} domain.EffectiveTaxonomyMapping
+// @Router /categories/{id}/taxonomies/{taxonomy} [get]
+func (a *TaxonomyHandler) Resolve(w http.ResponseWriter, r *http.Request) {
+	m, err := a.TUsecase.Resolve(r.Context(), chi.URLParam(r, "id"), chi.URLParam(r, "taxonomy"))
+	if err != nil {
+		respondErr(w, r, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, m)
+}
+
+type putTaxonomyRequest struct {
+	ExternalID string `json:"externalId"`
+}
+
+// Put godoc
+// @Summary Map category to taxonomy code
+// @Description Map a category, and the descendants without a mapping of their own, to a code of the taxonomy
+// @Tags taxonomies
+// @Accept json
+// @Produce json
+// @Param id path string true "Category ID"
+// @Param taxonomy path string true "Taxonomy name"
+// @Param request body putTaxonomyRequest true "External code"
+// @Success 200 {object} domain.TaxonomyMapping
+// @Router /categories/{id}/taxonomies/{taxonomy} [put]
+func (a *TaxonomyHandler) Put(w http.ResponseWriter, r *http.Request) {
+	var req putTaxonomyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	m := domain.TaxonomyMapping{
+		CategoryID: chi.URLParam(r, "id"),
+		Taxonomy:   chi.URLParam(r, "taxonomy"),
+		ExternalID: req.ExternalID,
+	}
+	if err := a.TUsecase.Put(r.Context(), &m); err != nil {
+		respondErr(w, r, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, m)
+}
+
+func (a *TaxonomyHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	if err := a.TUsecase.Delete(r.Context(), chi.URLParam(r, "id"), chi.URLParam(r, "taxonomy")); err != nil {
+		respondErr(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,129 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/tokobapak/catalog-service/internal/domain"
+)
+
+type TranslationHandler struct {
+	TUsecase domain.TranslationUsecase
+}
+
+func NewTranslationHandler(r *chi.Mux, us domain.TranslationUsecase) {
+	handler := &TranslationHandler{
+		TUsecase: us,
+	}
+
+	r.Get("/api/v1/categories/{id}/translations", handler.Fetch(domain.TranslatableCategory))
+	r.Put("/api/v1/categories/{id}/translations/{locale}", handler.Put(domain.TranslatableCategory))
+	r.Delete("/api/v1/categories/{id}/translations/{locale}", handler.Delete(domain.TranslatableCategory))
+
+	r.Get("/api/v1/brands/{id}/translations", handler.Fetch(domain.TranslatableBrand))
+	r.Put("/api/v1/brands/{id}/translations/{locale}", handler.Put(domain.TranslatableBrand))
+	r.Delete("/api/v1/brands/{id}/translations/{locale}", handler.Delete(domain.TranslatableBrand))
+}
+
+// Fetch godoc
+// @Summary List translations
+// @Description Get every translation of a category or brand
+// @Tags translations
+// @Produce json
+// @Param id path string true "Category or brand ID"
+// @Success 200 {object} map[string]interface{}
+// @Router /categories/{id}/translations [get]
+// @Router /brands/{id}/translations [get]
+func (a *TranslationHandler) Fetch(kind domain.TranslatableKind) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		list, err := a.TUsecase.Fetch(r.Context(), kind, chi.URLParam(r, "id"))
+		if err != nil {
+			respondErr(w, r, err)
+			return
+		}
+
+		respondJSON(w, http.StatusOK, map[string]interface{}{
+			"data": list,
+		})
+	}
+}
+
+type translationRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// Put godoc
+// @Summary Set translation
+// @Description Create or replace the translation of a category or brand for a locale other than id-ID. Brands have no description.
+// @Tags translations
+// @Accept json
+// @Produce json
+// @Param id path string true "Category or brand ID"
+// @Param locale path string true "BCP 47 locale, e.g. en-US"
+// @Param request body translationRequest true "Translated name and description"
+// @Success 200 {object} domain.Translation
+// @Router /categories/{id}/translations/{locale} [put]
+// @Router /brands/{id}/translations/{locale} [put]
+func (a *TranslationHandler) Put(kind domain.TranslatableKind) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req translationRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			respondError(w, r, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		t := domain.Translation{
+			Locale:      chi.URLParam(r, "locale"),
+			Name:        req.Name,
+			Description: req.Description,
+		}
+		if err := a.TUsecase.Put(r.Context(), kind, chi.URLParam(r, "id"), &t); err != nil {
+			respondErr(w, r, err)
+			return
+		}
+
+		respondJSON(w, http.StatusOK, t)
+	}
+}
+
+// Delete godoc
+// @Summary Delete translation
+// @Description Remove the translation of a category or brand for a locale
+// @Tags translations
+// @Param id path string true "Category or brand ID"
+// @Param locale path string true "BCP 47 locale, e.g. en-US"
+// @Success 204
+// @Router /categories/{id}/translations/{locale} [delete]
+// @Router /brands/{id}/translations/{locale} [delete]
+func (a *TranslationHandler) Delete(kind domain.TranslatableKind) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		err := a.TUsecase.Delete(r.Context(), kind, chi.URLParam(r, "id"), chi.URLParam(r, "locale"))
+		if err != nil {
+			respondErr(w, r, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// setContentLanguage marks a localized response: it varies with
+// Accept-Language and is written in locales.
+func setContentLanguage(w http.ResponseWriter, locales []string) {
+	w.Header().Add("Vary", "Accept-Language")
+
+	seen := make(map[string]bool, len(locales))
+	var unique []string
+	for _, l := range locales {
+		if l != "" && !seen[l] {
+			seen[l] = true
+			unique = append(unique, l)
+		}
+	}
+	if len(unique) > 0 {
+		w.Header().Set("Content-Language", strings.Join(unique, ", "))
+	}
+}
@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type AttributeType string
+
+const (
+	AttributeTypeText    AttributeType = "text"
+	AttributeTypeNumber  AttributeType = "number"
+	AttributeTypeEnum    AttributeType = "enum"
+	AttributeTypeBoolean AttributeType = "boolean"
+	AttributeTypeUnit    AttributeType = "unit"
+)
+
+func (t AttributeType) IsValid() bool {
+	switch t {
+	case AttributeTypeText, AttributeTypeNumber, AttributeTypeEnum, AttributeTypeBoolean, AttributeTypeUnit:
+		return true
+	}
+	return false
+}
+
+// Attribute defines a product property such as "Ukuran Layar" or "Warna".
+// For enum attributes AllowedValues lists the options; for unit attributes it
+// lists the accepted units (e.g. "g", "kg").
+type Attribute struct {
+	ID            string        `json:"id"`
+	Code          string        `json:"code"`
+	Name          string        `json:"name"`
+	Type          AttributeType `json:"type"`
+	AllowedValues []string      `json:"allowedValues,omitempty"`
+	IsRequired    bool          `json:"isRequired"`
+	IsFilterable  bool          `json:"isFilterable"`
+	CreatedAt     time.Time     `json:"createdAt"`
+	UpdatedAt     time.Time     `json:"updatedAt"`
+}
+
+// CategoryAttribute attaches an attribute to a category. IsRequired, when set,
+// overrides the attribute's own flag for the category and its descendants.
+type CategoryAttribute struct {
+	CategoryID string    `json:"categoryId"`
+	Attribute  Attribute `json:"attribute"`
+	IsRequired *bool     `json:"isRequired,omitempty"`
+}
+
+// EffectiveAttribute is an attribute as it applies to a category after
+// inheritance, with InheritedFrom naming the category it was attached to.
+type EffectiveAttribute struct {
+	Attribute
+	InheritedFrom string `json:"inheritedFrom"`
+}
+
+type AttributeRepository interface {
+	Fetch(ctx context.Context) ([]Attribute, error)
+	GetByID(ctx context.Context, id string) (Attribute, error)
+	GetByCode(ctx context.Context, code string) (Attribute, error)
+	Store(ctx context.Context, a *Attribute) error
+	Update(ctx context.Context, a *Attribute) error
+	Delete(ctx context.Context, id string) error
+	// GetByCategoryIDs returns the attributes attached directly to any of the given categories.
+	GetByCategoryIDs(ctx context.Context, categoryIDs []string) ([]CategoryAttribute, error)
+	Attach(ctx context.Context, categoryID, attributeID string, isRequired *bool) error
+	Detach(ctx context.Context, categoryID, attributeID string) error
+}
+
+type AttributeUsecase interface {
+	Fetch(ctx context.Context) ([]Attribute, error)
+	GetByID(ctx context.Context, id string) (Attribute, error)
+	Store(ctx context.Context, a *Attribute) error
+	Update(ctx context.Context, a *Attribute) error
+	Delete(ctx context.Context, id string) error
+	Attach(ctx context.Context, categoryID, attributeID string, isRequired *bool) error
+	Detach(ctx context.Context, categoryID, attributeID string) error
+	// GetEffective resolves the attributes that apply to a category, including
+	// those inherited from its ancestors. The nearest attachment wins.
+	GetEffective(ctx context.Context, categoryID string) ([]EffectiveAttribute, error)
+}
@@ -6,29 +6,103 @@ import (
 )
 
 type Brand struct {
-	ID        string    `json:"id"`
-	Name      string    `json:"name"`
-	Slug      string    `json:"slug"`
-	LogoURL   *string   `json:"logoUrl,omitempty"`
-	IsActive  bool      `json:"isActive"`
-	CreatedAt time.Time `json:"createdAt"`
-	UpdatedAt time.Time `json:"updatedAt"`
+	ID        string     `json:"id"`
+	Name      string     `json:"name"`
+	Slug      string     `json:"slug"`
+	LogoURL   *string    `json:"logoUrl,omitempty"`
+	IsActive  bool       `json:"isActive"`
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt time.Time  `json:"updatedAt"`
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
+	// MergedInto is the brand this one was merged into when it was deleted as
+	// a duplicate.
+	MergedInto *string `json:"mergedInto,omitempty"`
+	// Version is incremented by every write to the brand.
+	Version int64 `json:"version"`
+	// Locale is the language Name is in, set when the brand was localized
+	// for a client.
+	Locale string `json:"locale,omitempty"`
 }
 
+// BrandFilter narrows and orders the brands returned by Fetch.
+type BrandFilter struct {
+	IncludeDeleted bool
+	IsActive       *bool
+	// Query matches names and slugs by prefix, and names by similarity.
+	Query string
+	// CategoryIDs keeps brands associated with any of these categories.
+	CategoryIDs []string
+	Sort        Sort
+}
+
+// BrandRepository reads skip soft-deleted brands unless stated otherwise.
 type BrandRepository interface {
-	Fetch(ctx context.Context, cursor string, num int64) ([]Brand, string, error)
+	Fetch(ctx context.Context, filter BrandFilter, cursor string, num int64) ([]Brand, PageInfo, error)
 	GetByID(ctx context.Context, id string) (Brand, error)
 	GetBySlug(ctx context.Context, slug string) (Brand, error)
+	// BatchGet returns the brands among ids, in no particular order.
+	BatchGet(ctx context.Context, ids []string) ([]Brand, error)
+	// GetByFormerSlug returns the brand that used slug before it was renamed.
+	GetByFormerSlug(ctx context.Context, slug string) (Brand, error)
+	// SlugExists reports whether any brand, including soft-deleted ones, uses
+	// slug now or used it before.
+	SlugExists(ctx context.Context, slug string) (bool, error)
+	// Store saves a new brand and sets its Version.
 	Store(ctx context.Context, b *Brand) error
+	// Update fails with ErrVersionMismatch unless b.Version is the current
+	// version, or 0 to skip the check. On success b.Version is the new version.
 	Update(ctx context.Context, b *Brand) error
-	Delete(ctx context.Context, id string) error
+	// Delete soft-deletes a brand; version is checked as in Update.
+	Delete(ctx context.Context, id string, version int64) error
+	// Restore undeletes a brand; a brand that was merged fails with ErrConflict.
+	Restore(ctx context.Context, id string) error
+	// Merge hands the category links and slugs of brand id over to brand
+	// into, then soft-deletes id with MergedInto set. version is checked as
+	// in Update.
+	Merge(ctx context.Context, id, into string, version int64) error
+	// CategoryIDs returns the categories brand id is associated with directly.
+	CategoryIDs(ctx context.Context, id string) ([]string, error)
+	// AttachCategory associates a brand with a category; attaching twice is a no-op.
+	AttachCategory(ctx context.Context, id, categoryID string) error
+	// DetachCategory fails with ErrNotFound when the two were not associated.
+	DetachCategory(ctx context.Context, id, categoryID string) error
 }
 
 type BrandUsecase interface {
-	Fetch(ctx context.Context, cursor string, num int64) ([]Brand, string, error)
+	Fetch(ctx context.Context, filter BrandFilter, cursor string, num int64) ([]Brand, PageInfo, error)
 	GetByID(ctx context.Context, id string) (Brand, error)
 	GetBySlug(ctx context.Context, slug string) (Brand, error)
+	// ResolveSlug finds the brand by its current slug or, failing that, by a
+	// former one; moved reports the latter so callers can point at the
+	// canonical slug.
+	ResolveSlug(ctx context.Context, slug string) (b Brand, moved bool, err error)
+	// BatchGet returns the brands found for ids in request order, along with
+	// the ids that matched nothing.
+	BatchGet(ctx context.Context, ids []string) ([]Brand, []string, error)
 	Store(ctx context.Context, b *Brand) error
+	// Update overwrites the brand if b.Version is still current, or
+	// unconditionally when it is 0.
 	Update(ctx context.Context, b *Brand) error
-	Delete(ctx context.Context, id string) error
+	// Patch applies a merge patch to the brand at version, or at whatever
+	// version is current when it is 0, and returns the result.
+	Patch(ctx context.Context, id string, version int64, p BrandPatch) (Brand, error)
+	Delete(ctx context.Context, id string, version int64) error
+	Restore(ctx context.Context, id string) (Brand, error)
+	// Merge folds duplicate brand id at version into brand into, which takes
+	// over its categories and answers to its slug, and returns the surviving
+	// brand.
+	Merge(ctx context.Context, id, into string, version int64) (Brand, error)
+	// FetchByCategory lists the brands associated with a category, and with
+	// its descendants too when includeDescendants is set.
+	FetchByCategory(ctx context.Context, categoryID string, includeDescendants bool, filter BrandFilter, cursor string, num int64) ([]Brand, PageInfo, error)
+	// GetCategories lists the categories a brand is associated with, by name.
+	GetCategories(ctx context.Context, id string) ([]Category, error)
+	AttachCategory(ctx context.Context, id, categoryID string) error
+	DetachCategory(ctx context.Context, id, categoryID string) error
+	// History lists the revisions of a brand, newest first, including those
+	// recorded while it was deleted.
+	History(ctx context.Context, id, cursor string, num int64) ([]Revision, PageInfo, error)
+	// Revert rewrites the brand to how revision revisionID left it, as an
+	// ordinary update that is itself recorded as a revision.
+	Revert(ctx context.Context, id string, revisionID int64) (Brand, error)
 }
@@ -5,6 +5,12 @@ import (
 	"time"
 )
 
+// MaxCategoryDepth is the deepest level a category may sit at, counting roots as level 1.
+const MaxCategoryDepth = 5
+
+// MaxBatchSize caps the number of IDs accepted by a single BatchGet call.
+const MaxBatchSize = 100
+
 type Category struct {
 	ID          string     `json:"id"`
 	Name        string     `json:"name"`
@@ -15,26 +21,176 @@ type Category struct {
 	IconURL     *string    `json:"iconUrl,omitempty"`
 	DisplayOrder int       `json:"displayOrder"`
 	IsActive    bool       `json:"isActive"`
+	// VisibleFrom and VisibleUntil bound when an active category is shown to
+	// shoppers, for campaigns that open and close on schedule. Either may be
+	// nil for no bound; VisibleUntil is exclusive.
+	VisibleFrom  *time.Time `json:"visibleFrom,omitempty"`
+	VisibleUntil *time.Time `json:"visibleUntil,omitempty"`
 	CreatedAt   time.Time  `json:"createdAt"`
 	UpdatedAt   time.Time  `json:"updatedAt"`
+	DeletedAt   *time.Time `json:"deletedAt,omitempty"`
+	// MergedInto is the category this one was merged into when it was deleted
+	// as a duplicate.
+	MergedInto  *string    `json:"mergedInto,omitempty"`
+	// Version is incremented by every write to the category.
+	Version     int64      `json:"version"`
+	// Locale is the language Name and Description are in, set when the
+	// category was localized for a client.
+	Locale      string     `json:"locale,omitempty"`
+	Children    []Category `json:"children,omitempty"`
+}
+
+// VisibleAt reports whether t falls inside the visibility window of c.
+func (c Category) VisibleAt(t time.Time) bool {
+	return (c.VisibleFrom == nil || !t.Before(*c.VisibleFrom)) && (c.VisibleUntil == nil || t.Before(*c.VisibleUntil))
 }
 
+// CategoryFilter narrows and orders the categories returned by Fetch.
+type CategoryFilter struct {
+	IncludeDeleted bool
+	IsActive       *bool
+	// VisibleAt keeps the categories whose visibility window contains it.
+	VisibleAt *time.Time
+	// IncludeScheduled keeps categories outside their visibility window in
+	// the listings of CategoryUsecase, for admins.
+	IncludeScheduled bool
+	// ParentID keeps the children of one category; RootOnly keeps top-level
+	// categories instead.
+	ParentID *string
+	RootOnly bool
+	// Query matches names and slugs by prefix, and names by similarity.
+	Query string
+	Sort  Sort
+}
+
+// DeletePolicy decides what happens to the children of a deleted category.
+type DeletePolicy string
+
+const (
+	// DeletePolicyBlock refuses to delete a category that still has children.
+	DeletePolicyBlock DeletePolicy = "block"
+	// DeletePolicyCascade archives the whole subtree together with the category.
+	DeletePolicyCascade DeletePolicy = "cascade"
+	// DeletePolicyReparent hands the children over to the deleted category's parent.
+	DeletePolicyReparent DeletePolicy = "reparent"
+)
+
+func (p DeletePolicy) IsValid() bool {
+	switch p {
+	case DeletePolicyBlock, DeletePolicyCascade, DeletePolicyReparent:
+		return true
+	}
+	return false
+}
+
+// Breadcrumb is a single step of the trail from the root category to a leaf.
+type Breadcrumb struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+// CategoryRepository reads skip soft-deleted categories unless stated otherwise.
 type CategoryRepository interface {
-	Fetch(ctx context.Context, cursor string, num int64) ([]Category, string, error)
+	Fetch(ctx context.Context, filter CategoryFilter, cursor string, num int64) ([]Category, PageInfo, error)
 	GetByID(ctx context.Context, id string) (Category, error)
 	GetBySlug(ctx context.Context, slug string) (Category, error)
+	// BatchGet returns the categories among ids, in no particular order.
+	BatchGet(ctx context.Context, ids []string) ([]Category, error)
+	// GetByFormerSlug returns the category that used slug before it was renamed.
+	GetByFormerSlug(ctx context.Context, slug string) (Category, error)
+	// SlugExists reports whether any category, including soft-deleted ones, uses
+	// slug now or used it before.
+	SlugExists(ctx context.Context, slug string) (bool, error)
 	GetByParentID(ctx context.Context, parentID *string) ([]Category, error)
+	// GetTree returns every category reachable from the roots as a flat list
+	// ordered by depth and display order. A maxDepth of 0 means unlimited.
+	GetTree(ctx context.Context, maxDepth int, activeOnly bool) ([]Category, error)
+	// GetAncestors returns the chain from the root down to and including the
+	// category, or ErrNotFound when the category does not exist.
+	GetAncestors(ctx context.Context, id string) ([]Category, error)
+	// GetDescendants returns every category below id, ordered by depth and display order.
+	GetDescendants(ctx context.Context, id string) ([]Category, error)
+	// Move reparents a category and re-sequences the display order of its new
+	// siblings, inserting it at position (appending when position is negative).
+	Move(ctx context.Context, id string, parentID *string, position int) error
+	// Reorder rewrites the display order of the children of parentID to follow
+	// ids, failing with ErrOrderMismatch unless ids is exactly the current children.
+	Reorder(ctx context.Context, parentID *string, ids []string) error
+	// Store saves a new category and sets its Version.
 	Store(ctx context.Context, c *Category) error
+	// Update fails with ErrVersionMismatch unless c.Version is the current
+	// version, or 0 to skip the check. On success c.Version is the new version.
 	Update(ctx context.Context, c *Category) error
-	Delete(ctx context.Context, id string) error
+	// Delete soft-deletes a category, handling its children according to
+	// policy. version is checked as in Update.
+	Delete(ctx context.Context, id string, version int64, policy DeletePolicy) error
+	// Restore undeletes a category together with the descendants archived with
+	// it. A category that was merged fails with ErrConflict.
+	Restore(ctx context.Context, id string) error
+	// Merge hands the children, attribute, brand and taxonomy links and slugs
+	// of category id over to category into, then soft-deletes id with
+	// MergedInto set. version is checked as in Update.
+	Merge(ctx context.Context, id, into string, version int64) error
+	// Import inserts creates, in order, and then applies updates, checking
+	// their versions as Update does. Either everything is written or nothing.
+	Import(ctx context.Context, creates, updates []Category) error
+	// Export calls fn for every category with its slug path, parents before
+	// children, and stops at the first error fn returns.
+	Export(ctx context.Context, fn func(c Category, path string) error) error
+	// SyncVisibility finds the categories whose visibility window opened or
+	// closed since it was last recorded, as of now, records the new state and
+	// queues an EventCategoryWindowOpened or EventCategoryWindowClosed for
+	// each. It returns the number of categories changed.
+	SyncVisibility(ctx context.Context, now time.Time) (int, error)
 }
 
+// CategoryUsecase reads meant for shoppers treat categories outside their
+// visibility window as absent, together with their subtrees; admins pass
+// includeScheduled, or set CategoryFilter.IncludeScheduled, to see them.
 type CategoryUsecase interface {
-	Fetch(ctx context.Context, cursor string, num int64) ([]Category, string, error)
-	GetByID(ctx context.Context, id string) (Category, error)
-	GetBySlug(ctx context.Context, slug string) (Category, error)
-	GetTree(ctx context.Context) ([]Category, error)
+	Fetch(ctx context.Context, filter CategoryFilter, cursor string, num int64) ([]Category, PageInfo, error)
+	GetByID(ctx context.Context, id string, includeScheduled bool) (Category, error)
+	GetBySlug(ctx context.Context, slug string, includeScheduled bool) (Category, error)
+	// ResolveSlug finds the category by its current slug or, failing that, by a
+	// former one; moved reports the latter so callers can point at the
+	// canonical slug.
+	ResolveSlug(ctx context.Context, slug string, includeScheduled bool) (c Category, moved bool, err error)
+	// BatchGet returns the categories found for ids in request order, along
+	// with the ids that matched nothing. It resolves references held by other
+	// services, so visibility windows do not apply.
+	BatchGet(ctx context.Context, ids []string) ([]Category, []string, error)
+	GetTree(ctx context.Context, maxDepth int, activeOnly, includeScheduled bool) ([]Category, error)
+	GetChildren(ctx context.Context, id string, includeScheduled bool) ([]Category, error)
+	GetAncestors(ctx context.Context, id string, includeScheduled bool) ([]Category, error)
+	// GetDescendants returns the categories at most maxDepth levels below id,
+	// 0 for unlimited, ordered by depth and display order.
+	GetDescendants(ctx context.Context, id string, maxDepth int, includeScheduled bool) ([]Category, error)
+	Move(ctx context.Context, id string, parentID *string, position int) (Category, error)
+	Reorder(ctx context.Context, parentID *string, ids []string) ([]Category, error)
 	Store(ctx context.Context, c *Category) error
+	// Update overwrites the category if c.Version is still current, or
+	// unconditionally when it is 0.
 	Update(ctx context.Context, c *Category) error
-	Delete(ctx context.Context, id string) error
+	// Patch applies a merge patch to the category at version, or at whatever
+	// version is current when it is 0, and returns the result.
+	Patch(ctx context.Context, id string, version int64, p CategoryPatch) (Category, error)
+	Delete(ctx context.Context, id string, version int64, policy DeletePolicy) error
+	Restore(ctx context.Context, id string) (Category, error)
+	// Merge folds duplicate category id at version into category into, which
+	// takes over its children and associations and answers to its slug, and
+	// returns the surviving category.
+	Merge(ctx context.Context, id, into string, version int64) (Category, error)
+	// Import creates and updates categories from records, matching them to
+	// existing categories by slug. Nothing is written on a dry run or when
+	// any record fails; the report says what happened to each record.
+	Import(ctx context.Context, records []CategoryRecord, dryRun bool) (ImportReport, error)
+	// Export calls fn for every category, parents before children.
+	Export(ctx context.Context, fn func(CategoryRecord) error) error
+	// History lists the revisions of a category, newest first, including those
+	// recorded while it was deleted.
+	History(ctx context.Context, id, cursor string, num int64) ([]Revision, PageInfo, error)
+	// Revert rewrites the category to how revision revisionID left it, as an
+	// ordinary update that is itself recorded as a revision.
+	Revert(ctx context.Context, id string, revisionID int64) (Category, error)
 }
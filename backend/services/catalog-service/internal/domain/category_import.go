@@ -0,0 +1,54 @@
+package domain
+
+import "github.com/tokobapak/catalog-service/pkg/validator"
+
+// MaxImportRows caps the number of categories accepted by a single import.
+const MaxImportRows = 10000
+
+// CategoryRecord is one category of an import or export file. Path is the
+// slug path of the category itself, e.g. "elektronik/handphone/android": its
+// last segment is the slug and the rest names the parent. Nil fields keep
+// their current value on update and take the defaults on create.
+type CategoryRecord struct {
+	// Line is the position of the record in the import file, counting the
+	// CSV header as line 1.
+	Line         int     `json:"-"`
+	Path         string  `json:"path"`
+	Name         string  `json:"name"`
+	Description  *string `json:"description,omitempty"`
+	ImageURL     *string `json:"imageUrl,omitempty"`
+	IconURL      *string `json:"iconUrl,omitempty"`
+	DisplayOrder *int    `json:"displayOrder,omitempty"`
+	IsActive     *bool   `json:"isActive,omitempty"`
+}
+
+// ImportAction is what an import does, or would do, with a record.
+type ImportAction string
+
+const (
+	ImportCreate    ImportAction = "create"
+	ImportUpdate    ImportAction = "update"
+	ImportUnchanged ImportAction = "unchanged"
+	ImportError     ImportAction = "error"
+)
+
+// ImportRowResult reports the outcome of one record.
+type ImportRowResult struct {
+	Line   int              `json:"line"`
+	Path   string           `json:"path"`
+	Action ImportAction     `json:"action"`
+	ID     string           `json:"id,omitempty"`
+	Errors validator.Errors `json:"errors,omitempty"`
+}
+
+// ImportReport summarises an import. Nothing is written unless Applied: a
+// dry run or a single failed record leaves the catalog untouched.
+type ImportReport struct {
+	DryRun    bool              `json:"dryRun"`
+	Applied   bool              `json:"applied"`
+	Created   int               `json:"created"`
+	Updated   int               `json:"updated"`
+	Unchanged int               `json:"unchanged"`
+	Failed    int               `json:"failed"`
+	Rows      []ImportRowResult `json:"rows"`
+}
@@ -0,0 +1,16 @@
+package domain
+
+import "time"
+
+// Clock tells the time for decisions that depend on it, such as whether a
+// visibility window is open, so tests can set the time.
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock is the server clock.
+type SystemClock struct{}
+
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
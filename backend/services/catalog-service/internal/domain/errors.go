@@ -7,4 +7,12 @@ var (
 	ErrNotFound            = errors.New("your requested item is not found")
 	ErrConflict            = errors.New("your item already exists")
 	ErrBadParamInput       = errors.New("given param is not valid")
+	ErrCategoryCycle       = errors.New("category cannot be placed under itself or its descendants")
+	ErrMaxDepthExceeded    = errors.New("category tree exceeds the maximum depth")
+	ErrOrderMismatch       = errors.New("ordered ids do not match the current children")
+	ErrCategoryHasChildren = errors.New("category still has child categories")
+	ErrVersionMismatch     = errors.New("item was modified since the given version")
+	// ErrValidation marks well-formed input that breaks the rules of one or
+	// more fields; the chain also holds a validator.Errors listing them.
+	ErrValidation = errors.New("validation failed")
 )
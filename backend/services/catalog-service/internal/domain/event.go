@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// EventType names a change to a catalog aggregate as published to other services.
+type EventType string
+
+const (
+	EventCategoryCreated  EventType = "catalog.category.created"
+	EventCategoryUpdated  EventType = "catalog.category.updated"
+	EventCategoryDeleted  EventType = "catalog.category.deleted"
+	EventCategoryRestored EventType = "catalog.category.restored"
+	// EventCategoryWindowOpened and EventCategoryWindowClosed announce that the
+	// visibility window of a category opened or closed as time passed.
+	EventCategoryWindowOpened EventType = "catalog.category.window_opened"
+	EventCategoryWindowClosed EventType = "catalog.category.window_closed"
+	EventBrandCreated         EventType = "catalog.brand.created"
+	EventBrandUpdated         EventType = "catalog.brand.updated"
+	EventBrandDeleted         EventType = "catalog.brand.deleted"
+	EventBrandRestored        EventType = "catalog.brand.restored"
+	// EventCategoryMerged and EventBrandMerged announce that a duplicate was
+	// deleted in favour of the aggregate named by its mergedInto, so that
+	// references to it can be remapped.
+	EventCategoryMerged EventType = "catalog.category.merged"
+	EventBrandMerged    EventType = "catalog.brand.merged"
+)
+
+// Action is the past-tense verb at the end of t, such as "updated".
+func (t EventType) Action() string {
+	return string(t)[strings.LastIndex(string(t), ".")+1:]
+}
+
+const (
+	AggregateCategory = "category"
+	AggregateBrand    = "brand"
+)
+
+// Event is a change recorded in the outbox together with the write that caused
+// it. Payload is the JSON snapshot of the aggregate after the change.
+type Event struct {
+	ID            string
+	Type          EventType
+	AggregateType string
+	AggregateID   string
+	Payload       json.RawMessage
+	OccurredAt    time.Time
+}
+
+type OutboxRepository interface {
+	// PublishPending hands up to limit unpublished events to publish, oldest
+	// first and at most one per aggregate, and marks them published once it
+	// returns nil. Events of an aggregate whose earlier event is still pending
+	// are held back so each aggregate is published in order. An event that
+	// failed before is handed over alone, and one that keeps failing is
+	// dead-lettered so that it stops holding back its aggregate. The number of
+	// events published is returned; the error of publish is returned as is.
+	PublishPending(ctx context.Context, limit int, publish func(context.Context, []Event) error) (int, error)
+	// DeletePublished removes the events published before the given time and
+	// returns how many it removed. Dead-lettered events are kept.
+	DeletePublished(ctx context.Context, before time.Time) (int, error)
+}
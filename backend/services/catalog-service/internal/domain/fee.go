@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// MaxCommissionBasisPoints is a commission of 100%.
+const MaxCommissionBasisPoints = 10000
+
+// FeeRule is what the marketplace charges sellers on orders in a category
+// between EffectiveFrom and EffectiveTo, exclusive; a nil EffectiveTo leaves
+// the rule open-ended. The rules of one category never overlap. Categories
+// without a rule in force take the one of their nearest ancestor that has.
+type FeeRule struct {
+	ID         string `json:"id"`
+	CategoryID string `json:"categoryId"`
+	// CommissionBasisPoints is the commission in hundredths of a percent of
+	// the order value: 1250 is 12.5%.
+	CommissionBasisPoints int `json:"commissionBasisPoints"`
+	// FixedFee is charged per order on top of the commission, in rupiah.
+	FixedFee      int64      `json:"fixedFee"`
+	EffectiveFrom time.Time  `json:"effectiveFrom"`
+	EffectiveTo   *time.Time `json:"effectiveTo,omitempty"`
+	CreatedAt     time.Time  `json:"createdAt"`
+	UpdatedAt     time.Time  `json:"updatedAt"`
+}
+
+// InForce reports whether r applies at t.
+func (r FeeRule) InForce(t time.Time) bool {
+	return !t.Before(r.EffectiveFrom) && (r.EffectiveTo == nil || t.Before(*r.EffectiveTo))
+}
+
+// EffectiveFee is the fee rule that applies to a category at a point in time,
+// with InheritedFrom naming the category the rule was set on.
+type EffectiveFee struct {
+	FeeRule
+	At            time.Time `json:"at"`
+	InheritedFrom string    `json:"inheritedFrom"`
+}
+
+type FeeRuleRepository interface {
+	// FetchByCategory returns the rules set on a category, oldest first.
+	FetchByCategory(ctx context.Context, categoryID string) ([]FeeRule, error)
+	// GetInForce returns the rules of any of the given categories in force at t.
+	GetInForce(ctx context.Context, categoryIDs []string, t time.Time) ([]FeeRule, error)
+	GetByID(ctx context.Context, id string) (FeeRule, error)
+	// Store and Update answer ErrConflict when the rule would overlap another
+	// rule of its category.
+	Store(ctx context.Context, r *FeeRule) error
+	Update(ctx context.Context, r *FeeRule) error
+	Delete(ctx context.Context, id string) error
+}
+
+type FeeRuleUsecase interface {
+	FetchByCategory(ctx context.Context, categoryID string) ([]FeeRule, error)
+	Store(ctx context.Context, r *FeeRule) error
+	// Update replaces the rule r.ID, which must belong to r.CategoryID.
+	Update(ctx context.Context, r *FeeRule) error
+	Delete(ctx context.Context, categoryID, id string) error
+	// GetEffective resolves the rule that applies to a category at t, looking
+	// up the tree from the category itself, or returns ErrNotFound when no
+	// ancestor has a rule in force. Only the rules are taken as of t: the
+	// ancestors are those the category has now, even if it moved since.
+	GetEffective(ctx context.Context, categoryID string, t time.Time) (EffectiveFee, error)
+}
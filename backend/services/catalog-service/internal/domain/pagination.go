@@ -0,0 +1,34 @@
+package domain
+
+const (
+	// DefaultPageSize is used when a listing does not ask for a page size.
+	DefaultPageSize = 10
+	// MaxPageSize is the largest page a listing returns, whatever was asked for.
+	MaxPageSize = 100
+)
+
+// SortField names what a listing is ordered by. Each repository decides which
+// fields it supports and rejects the rest with ErrBadParamInput.
+type SortField string
+
+const (
+	SortByCreatedAt    SortField = "createdAt"
+	SortByName         SortField = "name"
+	SortByDisplayOrder SortField = "displayOrder"
+)
+
+// Sort orders a listing; ties are always broken by ID in the same direction.
+type Sort struct {
+	Field SortField
+	Desc  bool
+}
+
+// PageInfo describes where a page sits in a cursor-paginated listing. The
+// cursors are opaque; an empty cursor means there is nothing in that direction.
+type PageInfo struct {
+	NextCursor string `json:"nextCursor"`
+	PrevCursor string `json:"prevCursor"`
+	HasMore    bool   `json:"hasMore"`
+	// Total counts every row matching the filter, across all pages.
+	Total int64 `json:"total"`
+}
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// PatchField is one member of a JSON merge patch (RFC 7396). Set is false when
+// the member was absent and the field is left alone; Null is true when it was
+// explicitly null and the field is to be cleared.
+type PatchField[T any] struct {
+	Set   bool
+	Null  bool
+	Value T
+}
+
+func (f *PatchField[T]) UnmarshalJSON(b []byte) error {
+	f.Set = true
+	if string(b) == "null" {
+		f.Null = true
+		return nil
+	}
+	return json.Unmarshal(b, &f.Value)
+}
+
+// CategoryPatch is a merge patch of the editable fields of a category.
+// parentId, imageUrl, iconUrl and the visibility window may be nulled; name,
+// slug and isActive may not.
+type CategoryPatch struct {
+	Name         PatchField[string]    `json:"name" swaggertype:"string"`
+	Slug         PatchField[string]    `json:"slug" swaggertype:"string"`
+	Description  PatchField[string]    `json:"description" swaggertype:"string"`
+	ParentID     PatchField[string]    `json:"parentId" swaggertype:"string"`
+	ImageURL     PatchField[string]    `json:"imageUrl" swaggertype:"string"`
+	IconURL      PatchField[string]    `json:"iconUrl" swaggertype:"string"`
+	IsActive     PatchField[bool]      `json:"isActive" swaggertype:"boolean"`
+	VisibleFrom  PatchField[time.Time] `json:"visibleFrom" swaggertype:"string" format:"date-time"`
+	VisibleUntil PatchField[time.Time] `json:"visibleUntil" swaggertype:"string" format:"date-time"`
+}
+
+// BrandPatch is a merge patch of the editable fields of a brand. logoUrl may
+// be nulled; name, slug and isActive may not.
+type BrandPatch struct {
+	Name     PatchField[string] `json:"name" swaggertype:"string"`
+	Slug     PatchField[string] `json:"slug" swaggertype:"string"`
+	LogoURL  PatchField[string] `json:"logoUrl" swaggertype:"string"`
+	IsActive PatchField[bool]   `json:"isActive" swaggertype:"boolean"`
+}
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+const (
+	// AnonymousActor is recorded for requests that carry no user ID.
+	AnonymousActor = "anonymous"
+	// SystemActor is recorded for changes made outside any request.
+	SystemActor = "system"
+)
+
+// Revision is one recorded mutation of a category or brand. Before and After
+// are JSON snapshots of the entity; Before is null for a creation.
+type Revision struct {
+	ID         int64                  `json:"id"`
+	EntityType string                 `json:"entityType"`
+	EntityID   string                 `json:"entityId"`
+	Action     string                 `json:"action"`
+	Actor      string                 `json:"actor"`
+	Before     json.RawMessage        `json:"before"`
+	After      json.RawMessage        `json:"after"`
+	Diff       map[string]FieldChange `json:"diff"`
+	CreatedAt  time.Time              `json:"createdAt"`
+}
+
+// FieldChange is the old and new JSON value of one field; a missing side is null.
+type FieldChange struct {
+	From json.RawMessage `json:"from"`
+	To   json.RawMessage `json:"to"`
+}
+
+type RevisionRepository interface {
+	// Fetch lists the revisions of one entity, newest first.
+	Fetch(ctx context.Context, entityType, entityID, cursor string, num int64) ([]Revision, PageInfo, error)
+	GetByID(ctx context.Context, id int64) (Revision, error)
+}
+
+type actorKey struct{}
+
+// WithActor returns a copy of ctx naming actor as the author of the changes made with it.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns the actor stored by WithActor, or SystemActor.
+func ActorFromContext(ctx context.Context) string {
+	if actor, ok := ctx.Value(actorKey{}).(string); ok && actor != "" {
+		return actor
+	}
+	return SystemActor
+}
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// TaxonomyMapping assigns a category the code of one of its counterparts in
+// an external taxonomy, such as a marketplace's category tree. Descendants
+// without a mapping of their own inherit it.
+type TaxonomyMapping struct {
+	CategoryID string    `json:"categoryId"`
+	Taxonomy   string    `json:"taxonomy"`
+	ExternalID string    `json:"externalId"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
+// EffectiveTaxonomyMapping is the external code that applies to a category
+// after inheritance, with InheritedFrom naming the category it was mapped on.
+// ExternalPath is empty when the code is no longer in the taxonomy file.
+type EffectiveTaxonomyMapping struct {
+	CategoryID    string `json:"categoryId"`
+	Taxonomy      string `json:"taxonomy"`
+	ExternalID    string `json:"externalId"`
+	ExternalPath  string `json:"externalPath,omitempty"`
+	InheritedFrom string `json:"inheritedFrom"`
+}
+
+// TaxonomyInfo describes a taxonomy loaded from the taxonomy directory.
+type TaxonomyInfo struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+	Size    int    `json:"size"`
+}
+
+type TaxonomyRepository interface {
+	// GetByCategoryIDs returns the mappings of any of the given categories.
+	GetByCategoryIDs(ctx context.Context, categoryIDs []string) ([]TaxonomyMapping, error)
+	// FetchByTaxonomy returns every mapping into taxonomy.
+	FetchByTaxonomy(ctx context.Context, taxonomy string) ([]TaxonomyMapping, error)
+	// Put creates or replaces the mapping of a category into a taxonomy.
+	Put(ctx context.Context, m *TaxonomyMapping) error
+	Delete(ctx context.Context, categoryID, taxonomy string) error
+}
+
+type TaxonomyUsecase interface {
+	// Taxonomies lists the loaded taxonomies by name.
+	Taxonomies(ctx context.Context) []TaxonomyInfo
+	// Put maps a category to an external code, which must exist in the
+	// taxonomy file.
+	Put(ctx context.Context, m *TaxonomyMapping) error
+	Delete(ctx context.Context, categoryID, taxonomy string) error
+	// GetEffective resolves the code that applies to a category in every
+	// loaded taxonomy where one does. The nearest mapping wins.
+	GetEffective(ctx context.Context, categoryID string) ([]EffectiveTaxonomyMapping, error)
+	// Resolve resolves the code that applies to a category in one taxonomy,
+	// or returns ErrNotFound when neither it nor an ancestor is mapped.
+	Resolve(ctx context.Context, categoryID, taxonomy string) (EffectiveTaxonomyMapping, error)
+	// Unmapped returns the leaf categories that resolve to no code, or to
+	// one missing from the taxonomy file, in tree order.
+	Unmapped(ctx context.Context, taxonomy string) ([]Category, error)
+}
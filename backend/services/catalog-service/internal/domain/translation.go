@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// DefaultLocale is the language catalog content is authored in. Names and
+// descriptions stored on categories and brands themselves are in this locale,
+// and it is served whenever no translation suits the client.
+const DefaultLocale = "id-ID"
+
+// TranslatableKind names the kind of entity a translation belongs to.
+type TranslatableKind string
+
+const (
+	TranslatableCategory TranslatableKind = "category"
+	TranslatableBrand    TranslatableKind = "brand"
+)
+
+// Translation is the name, and for categories the description, of an entity
+// in one locale other than DefaultLocale.
+type Translation struct {
+	Locale      string    `json:"locale"`
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+type TranslationRepository interface {
+	// GetByOwners returns the translations of every listed entity, keyed by entity ID.
+	GetByOwners(ctx context.Context, kind TranslatableKind, ids []string) (map[string][]Translation, error)
+	// Upsert creates or replaces the translation for t.Locale.
+	Upsert(ctx context.Context, kind TranslatableKind, id string, t *Translation) error
+	Delete(ctx context.Context, kind TranslatableKind, id, locale string) error
+}
+
+type TranslationUsecase interface {
+	Fetch(ctx context.Context, kind TranslatableKind, id string) ([]Translation, error)
+	Put(ctx context.Context, kind TranslatableKind, id string, t *Translation) error
+	Delete(ctx context.Context, kind TranslatableKind, id, locale string) error
+	// LocalizeCategories rewrites the names and descriptions of list, including
+	// nested children, to the translation that best matches acceptLanguage (an
+	// Accept-Language header value) and records the chosen locale on each.
+	LocalizeCategories(ctx context.Context, acceptLanguage string, list []Category) error
+	// LocalizeBrands is LocalizeCategories for brands.
+	LocalizeBrands(ctx context.Context, acceptLanguage string, list []Brand) error
+}
@@ -0,0 +1,48 @@
+package event
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/tokobapak/catalog-service/internal/domain"
+)
+
+// OutboxCleaner deletes published events from the outbox once they are older
+// than the retention period, so the table only grows with the backlog.
+type OutboxCleaner struct {
+	outbox    domain.OutboxRepository
+	clock     domain.Clock
+	retention time.Duration
+	interval  time.Duration
+}
+
+// NewOutboxCleaner deletes the events published more than retention ago,
+// checking every interval.
+func NewOutboxCleaner(outbox domain.OutboxRepository, clock domain.Clock, retention, interval time.Duration) *OutboxCleaner {
+	return &OutboxCleaner{
+		outbox:    outbox,
+		clock:     clock,
+		retention: retention,
+		interval:  interval,
+	}
+}
+
+// Run cleans up until ctx is done. A failed run is logged and retried at the
+// next tick.
+func (c *OutboxCleaner) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := c.outbox.DeletePublished(ctx, c.clock.Now().Add(-c.retention)); err != nil && ctx.Err() == nil {
+			log.Printf("outbox cleaner: %v (retrying in %s)", err, c.interval)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/tokobapak/catalog-service/internal/domain"
+)
+
+type stubCleanupOutbox struct {
+	domain.OutboxRepository
+	before chan time.Time
+}
+
+func (s *stubCleanupOutbox) DeletePublished(_ context.Context, before time.Time) (int, error) {
+	select {
+	case s.before <- before:
+	default:
+	}
+	return 0, errors.New("database unavailable")
+}
+
+func TestOutboxCleanerDeletesPastRetention(t *testing.T) {
+	now := time.Date(2025, time.December, 12, 0, 0, 0, 0, time.UTC)
+	outbox := &stubCleanupOutbox{before: make(chan time.Time, 10)}
+	c := NewOutboxCleaner(outbox, fixedClock(now), 7*24*time.Hour, time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		c.Run(ctx)
+		close(done)
+	}()
+
+	// A failed run does not stop the cleaner.
+	for i := 0; i < 3; i++ {
+		select {
+		case got := <-outbox.before:
+			if want := now.AddDate(0, 0, -7); !got.Equal(want) {
+				t.Fatalf("expected events published before %s to go, got %s", want, got)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("cleaner stopped running")
+		}
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("cleaner did not stop with its context")
+	}
+}
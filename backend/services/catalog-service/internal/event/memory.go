@@ -0,0 +1,53 @@
+package event
+
+import (
+	"context"
+	"sync"
+
+	"github.com/tokobapak/catalog-service/internal/domain"
+)
+
+// MemoryPublisher keeps published events in memory, standing in for Kafka in
+// tests. Failures can be queued with FailNext.
+type MemoryPublisher struct {
+	mu       sync.Mutex
+	events   []domain.Event
+	failures []error
+}
+
+func NewMemoryPublisher() *MemoryPublisher {
+	return &MemoryPublisher{}
+}
+
+func (p *MemoryPublisher) Publish(_ context.Context, events []domain.Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.failures) > 0 {
+		err := p.failures[0]
+		p.failures = p.failures[1:]
+		return err
+	}
+
+	p.events = append(p.events, events...)
+	return nil
+}
+
+// FailNext makes the next call to Publish fail with err without keeping its
+// events. Calls queue up, one failure per call.
+func (p *MemoryPublisher) FailNext(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.failures = append(p.failures, err)
+}
+
+// Events returns everything published so far, in publish order.
+func (p *MemoryPublisher) Events() []domain.Event {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]domain.Event(nil), p.events...)
+}
+
+func (p *MemoryPublisher) Close() error {
+	return nil
+}
@@ -0,0 +1,83 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/segmentio/kafka-go"
+
+	"github.com/tokobapak/catalog-service/internal/domain"
+)
+
+// EnvelopeVersion is the version of the envelope events are published in.
+const EnvelopeVersion = 1
+
+// Publisher delivers outbox events to other services. Publish returns nil
+// only once every event has been accepted, in the order given.
+type Publisher interface {
+	Publish(ctx context.Context, events []domain.Event) error
+	Close() error
+}
+
+// envelope is the wire format shared by all TokoBapak domain events.
+type envelope struct {
+	EventID       string          `json:"eventId"`
+	EventType     string          `json:"eventType"`
+	EventTime     int64           `json:"eventTime"`
+	AggregateID   string          `json:"aggregateId"`
+	AggregateType string          `json:"aggregateType"`
+	Version       int             `json:"version"`
+	Payload       json.RawMessage `json:"payload"`
+}
+
+func newEnvelope(e domain.Event) envelope {
+	return envelope{
+		EventID:       e.ID,
+		EventType:     string(e.Type),
+		EventTime:     e.OccurredAt.UnixMilli(),
+		AggregateID:   e.AggregateID,
+		AggregateType: e.AggregateType,
+		Version:       EnvelopeVersion,
+		Payload:       e.Payload,
+	}
+}
+
+type kafkaPublisher struct {
+	writer *kafka.Writer
+}
+
+// NewKafkaPublisher publishes to topic, keyed by aggregate ID so every event
+// of an aggregate lands on the same partition and is consumed in order.
+func NewKafkaPublisher(brokers []string, topic string) Publisher {
+	return &kafkaPublisher{
+		writer: &kafka.Writer{
+			Addr:         kafka.TCP(brokers...),
+			Topic:        topic,
+			Balancer:     &kafka.Hash{},
+			RequiredAcks: kafka.RequireAll,
+		},
+	}
+}
+
+func (p *kafkaPublisher) Publish(ctx context.Context, events []domain.Event) error {
+	msgs := make([]kafka.Message, 0, len(events))
+	for _, e := range events {
+		value, err := json.Marshal(newEnvelope(e))
+		if err != nil {
+			return err
+		}
+		msgs = append(msgs, kafka.Message{
+			Key:   []byte(e.AggregateID),
+			Value: value,
+			Headers: []kafka.Header{
+				{Key: "eventType", Value: []byte(e.Type)},
+			},
+		})
+	}
+
+	return p.writer.WriteMessages(ctx, msgs...)
+}
+
+func (p *kafkaPublisher) Close() error {
+	return p.writer.Close()
+}
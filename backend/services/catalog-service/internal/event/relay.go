@@ -0,0 +1,68 @@
+package event
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/tokobapak/catalog-service/internal/domain"
+)
+
+const (
+	defaultBatchSize  = 100
+	defaultMaxBackoff = time.Minute
+)
+
+// Relay moves events from the outbox to a Publisher. Delivery is at least
+// once: a batch that fails to publish is retried as a whole, so consumers must
+// tolerate duplicates, which they can recognise by event ID.
+type Relay struct {
+	outbox     domain.OutboxRepository
+	publisher  Publisher
+	interval   time.Duration
+	batchSize  int
+	maxBackoff time.Duration
+}
+
+// NewRelay polls outbox every interval while it is empty.
+func NewRelay(outbox domain.OutboxRepository, publisher Publisher, interval time.Duration) *Relay {
+	return &Relay{
+		outbox:     outbox,
+		publisher:  publisher,
+		interval:   interval,
+		batchSize:  defaultBatchSize,
+		maxBackoff: defaultMaxBackoff,
+	}
+}
+
+// Run relays events until ctx is done. It keeps going without pause while the
+// outbox has pending events, and after a failure waits twice as long as after
+// the previous one, up to a minute.
+func (r *Relay) Run(ctx context.Context) {
+	var (
+		wait    time.Duration
+		backoff = r.interval
+	)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+
+		n, err := r.outbox.PublishPending(ctx, r.batchSize, r.publisher.Publish)
+		switch {
+		case err != nil:
+			if ctx.Err() != nil {
+				return
+			}
+			log.Printf("outbox relay: %v (retrying in %s)", err, backoff)
+			wait = backoff
+			backoff = min(2*backoff, r.maxBackoff)
+		case n > 0:
+			wait, backoff = 0, r.interval
+		default:
+			wait, backoff = r.interval, r.interval
+		}
+	}
+}
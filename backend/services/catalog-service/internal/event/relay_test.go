@@ -0,0 +1,126 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/tokobapak/catalog-service/internal/domain"
+)
+
+// stubOutbox mimics the postgres outbox: it hands out the oldest pending
+// event of each aggregate and marks a batch published only when publish succeeds.
+type stubOutbox struct {
+	domain.OutboxRepository
+	mu      sync.Mutex
+	pending []domain.Event
+	calls   int
+}
+
+func (s *stubOutbox) PublishPending(ctx context.Context, limit int, publish func(context.Context, []domain.Event) error) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls++
+
+	var (
+		batch []domain.Event
+		seen  = map[string]bool{}
+	)
+	for _, e := range s.pending {
+		if len(batch) == limit {
+			break
+		}
+		if seen[e.AggregateID] {
+			continue
+		}
+		seen[e.AggregateID] = true
+		batch = append(batch, e)
+	}
+	if len(batch) == 0 {
+		return 0, nil
+	}
+
+	if err := publish(ctx, batch); err != nil {
+		return 0, err
+	}
+
+	published := map[string]bool{}
+	for _, e := range batch {
+		published[e.ID] = true
+	}
+	rest := s.pending[:0]
+	for _, e := range s.pending {
+		if !published[e.ID] {
+			rest = append(rest, e)
+		}
+	}
+	s.pending = rest
+
+	return len(batch), nil
+}
+
+func TestRelayRetriesAndKeepsAggregateOrder(t *testing.T) {
+	outbox := &stubOutbox{pending: []domain.Event{
+		{ID: "e1", Type: domain.EventCategoryCreated, AggregateID: "c1"},
+		{ID: "e2", Type: domain.EventCategoryUpdated, AggregateID: "c1"},
+		{ID: "e3", Type: domain.EventBrandCreated, AggregateID: "b1"},
+		{ID: "e4", Type: domain.EventCategoryDeleted, AggregateID: "c1"},
+	}}
+	publisher := NewMemoryPublisher()
+	publisher.FailNext(errors.New("broker unavailable"))
+	publisher.FailNext(errors.New("broker unavailable"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan struct{})
+	go func() {
+		NewRelay(outbox, publisher, time.Millisecond).Run(ctx)
+		close(done)
+	}()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for len(publisher.Events()) < 4 {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected 4 events to be relayed, got %+v", publisher.Events())
+		}
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	<-done
+
+	var order []string
+	for _, e := range publisher.Events() {
+		if e.AggregateID == "c1" {
+			order = append(order, e.ID)
+		}
+	}
+	if len(order) != 3 || order[0] != "e1" || order[1] != "e2" || order[2] != "e4" {
+		t.Fatalf("expected c1 events in order e1, e2, e4, got %v", order)
+	}
+	if outbox.calls < 5 {
+		t.Fatalf("expected the two failed batches to be retried, got %d calls", outbox.calls)
+	}
+}
+
+func TestEnvelope(t *testing.T) {
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	raw, err := json.Marshal(newEnvelope(domain.Event{
+		ID:            "e1",
+		Type:          domain.EventBrandUpdated,
+		AggregateType: domain.AggregateBrand,
+		AggregateID:   "b1",
+		Payload:       json.RawMessage(`{"id":"b1"}`),
+		OccurredAt:    at,
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"eventId":"e1","eventType":"catalog.brand.updated","eventTime":1767323045000,"aggregateId":"b1","aggregateType":"brand","version":1,"payload":{"id":"b1"}}`
+	if string(raw) != want {
+		t.Fatalf("unexpected envelope:\n got %s\nwant %s", raw, want)
+	}
+}
@@ -0,0 +1,47 @@
+package event
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/tokobapak/catalog-service/internal/domain"
+)
+
+// VisibilityScheduler announces category visibility windows opening and
+// closing. The announcements are queued in the outbox like any other change,
+// so several instances may run it: each transition is claimed by one.
+type VisibilityScheduler struct {
+	categories domain.CategoryRepository
+	clock      domain.Clock
+	interval   time.Duration
+}
+
+// NewVisibilityScheduler checks categories every interval, which bounds how
+// late an announcement can be.
+func NewVisibilityScheduler(categories domain.CategoryRepository, clock domain.Clock, interval time.Duration) *VisibilityScheduler {
+	return &VisibilityScheduler{
+		categories: categories,
+		clock:      clock,
+		interval:   interval,
+	}
+}
+
+// Run syncs visibility windows until ctx is done. A failed run is logged and
+// retried at the next tick.
+func (s *VisibilityScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.categories.SyncVisibility(ctx, s.clock.Now()); err != nil && ctx.Err() == nil {
+			log.Printf("visibility scheduler: %v (retrying in %s)", err, s.interval)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
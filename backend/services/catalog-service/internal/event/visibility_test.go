@@ -0,0 +1,61 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/tokobapak/catalog-service/internal/domain"
+)
+
+type stubVisibilityRepo struct {
+	domain.CategoryRepository
+	synced chan time.Time
+}
+
+func (s *stubVisibilityRepo) SyncVisibility(_ context.Context, now time.Time) (int, error) {
+	select {
+	case s.synced <- now:
+	default:
+	}
+	return 0, errors.New("database unavailable")
+}
+
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time {
+	return time.Time(c)
+}
+
+func TestVisibilitySchedulerSyncsOnEveryTick(t *testing.T) {
+	now := time.Date(2025, time.December, 12, 0, 0, 0, 0, time.UTC)
+	repo := &stubVisibilityRepo{synced: make(chan time.Time, 10)}
+	s := NewVisibilityScheduler(repo, fixedClock(now), time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		s.Run(ctx)
+		close(done)
+	}()
+
+	// A failed run does not stop the scheduler.
+	for i := 0; i < 3; i++ {
+		select {
+		case got := <-repo.synced:
+			if !got.Equal(now) {
+				t.Fatalf("expected the clock's time, got %s", got)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("scheduler stopped syncing")
+		}
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("scheduler did not stop with its context")
+	}
+}
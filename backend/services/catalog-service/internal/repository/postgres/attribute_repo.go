@@ -0,0 +1,188 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/lib/pq"
+	"github.com/tokobapak/catalog-service/internal/domain"
+)
+
+type postgresAttributeRepo struct {
+	DB *sql.DB
+}
+
+func NewPostgresAttributeRepository(db *sql.DB) domain.AttributeRepository {
+	return &postgresAttributeRepo{
+		DB: db,
+	}
+}
+
+func (p *postgresAttributeRepo) fetch(ctx context.Context, query string, args ...interface{}) ([]domain.Attribute, error) {
+	rows, err := p.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []domain.Attribute
+	for rows.Next() {
+		var t domain.Attribute
+		err = rows.Scan(
+			&t.ID,
+			&t.Code,
+			&t.Name,
+			&t.Type,
+			pq.Array(&t.AllowedValues),
+			&t.IsRequired,
+			&t.IsFilterable,
+			&t.CreatedAt,
+			&t.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, t)
+	}
+
+	return result, rows.Err()
+}
+
+func (p *postgresAttributeRepo) Fetch(ctx context.Context) ([]domain.Attribute, error) {
+	query := `SELECT id, code, name, type, allowed_values, is_required, is_filterable, created_at, updated_at
+			  FROM attributes ORDER BY name`
+
+	return p.fetch(ctx, query)
+}
+
+func (p *postgresAttributeRepo) GetByID(ctx context.Context, id string) (domain.Attribute, error) {
+	query := `SELECT id, code, name, type, allowed_values, is_required, is_filterable, created_at, updated_at
+			  FROM attributes WHERE id = $1`
+
+	list, err := p.fetch(ctx, query, id)
+	if err != nil {
+		return domain.Attribute{}, err
+	}
+
+	if len(list) > 0 {
+		return list[0], nil
+	}
+
+	return domain.Attribute{}, domain.ErrNotFound
+}
+
+func (p *postgresAttributeRepo) GetByCode(ctx context.Context, code string) (domain.Attribute, error) {
+	query := `SELECT id, code, name, type, allowed_values, is_required, is_filterable, created_at, updated_at
+			  FROM attributes WHERE code = $1`
+
+	list, err := p.fetch(ctx, query, code)
+	if err != nil {
+		return domain.Attribute{}, err
+	}
+
+	if len(list) > 0 {
+		return list[0], nil
+	}
+
+	return domain.Attribute{}, domain.ErrNotFound
+}
+
+func (p *postgresAttributeRepo) Store(ctx context.Context, a *domain.Attribute) error {
+	query := `INSERT INTO attributes (id, code, name, type, allowed_values, is_required, is_filterable, created_at, updated_at)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	_, err := p.DB.ExecContext(ctx, query, a.ID, a.Code, a.Name, a.Type, pq.Array(a.AllowedValues), a.IsRequired, a.IsFilterable, a.CreatedAt, a.UpdatedAt)
+	return translateError(err)
+}
+
+func (p *postgresAttributeRepo) Update(ctx context.Context, a *domain.Attribute) error {
+	query := `UPDATE attributes SET code=$2, name=$3, type=$4, allowed_values=$5, is_required=$6, is_filterable=$7, updated_at=$8
+			  WHERE id=$1`
+
+	res, err := p.DB.ExecContext(ctx, query, a.ID, a.Code, a.Name, a.Type, pq.Array(a.AllowedValues), a.IsRequired, a.IsFilterable, a.UpdatedAt)
+	if err != nil {
+		return translateError(err)
+	}
+
+	return expectOneRow(res)
+}
+
+func (p *postgresAttributeRepo) Delete(ctx context.Context, id string) error {
+	res, err := p.DB.ExecContext(ctx, `DELETE FROM attributes WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	return expectOneRow(res)
+}
+
+func (p *postgresAttributeRepo) GetByCategoryIDs(ctx context.Context, categoryIDs []string) ([]domain.CategoryAttribute, error) {
+	query := `SELECT ca.category_id, ca.is_required,
+					 a.id, a.code, a.name, a.type, a.allowed_values, a.is_required, a.is_filterable, a.created_at, a.updated_at
+			  FROM category_attributes ca
+			  JOIN attributes a ON a.id = ca.attribute_id
+			  WHERE ca.category_id = ANY($1)
+			  ORDER BY a.name`
+
+	rows, err := p.DB.QueryContext(ctx, query, pq.Array(categoryIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []domain.CategoryAttribute
+	for rows.Next() {
+		var t domain.CategoryAttribute
+		err = rows.Scan(
+			&t.CategoryID,
+			&t.IsRequired,
+			&t.Attribute.ID,
+			&t.Attribute.Code,
+			&t.Attribute.Name,
+			&t.Attribute.Type,
+			pq.Array(&t.Attribute.AllowedValues),
+			&t.Attribute.IsRequired,
+			&t.Attribute.IsFilterable,
+			&t.Attribute.CreatedAt,
+			&t.Attribute.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, t)
+	}
+
+	return result, rows.Err()
+}
+
+func (p *postgresAttributeRepo) Attach(ctx context.Context, categoryID, attributeID string, isRequired *bool) error {
+	query := `INSERT INTO category_attributes (category_id, attribute_id, is_required)
+			  VALUES ($1, $2, $3)
+			  ON CONFLICT (category_id, attribute_id) DO UPDATE SET is_required = EXCLUDED.is_required`
+
+	_, err := p.DB.ExecContext(ctx, query, categoryID, attributeID, isRequired)
+	return translateError(err)
+}
+
+func (p *postgresAttributeRepo) Detach(ctx context.Context, categoryID, attributeID string) error {
+	res, err := p.DB.ExecContext(ctx, `DELETE FROM category_attributes WHERE category_id = $1 AND attribute_id = $2`, categoryID, attributeID)
+	if err != nil {
+		return err
+	}
+
+	return expectOneRow(res)
+}
+
+// expectOneRow turns an exec result that touched no rows into ErrNotFound.
+func expectOneRow(res sql.Result) error {
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return domain.ErrNotFound
+	}
+
+	return nil
+}
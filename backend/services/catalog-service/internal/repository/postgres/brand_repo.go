@@ -6,9 +6,18 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/lib/pq"
 	"github.com/tokobapak/catalog-service/internal/domain"
 )
 
+// brandColumns is the column list scanned by fetch, in order.
+const brandColumns = `id, name, slug, logo_url, is_active, created_at, updated_at, deleted_at, merged_into, version`
+
+var brandSorts = map[domain.SortField]sortColumn[domain.Brand]{
+	domain.SortByCreatedAt: {"created_at", "timestamptz", func(b domain.Brand) string { return b.CreatedAt.Format(time.RFC3339Nano) }},
+	domain.SortByName:      {"name", "text", func(b domain.Brand) string { return b.Name }},
+}
+
 type postgresBrandRepo struct {
 	DB *sql.DB
 }
@@ -20,7 +29,11 @@ func NewPostgresBrandRepository(db *sql.DB) domain.BrandRepository {
 }
 
 func (p *postgresBrandRepo) fetch(ctx context.Context, query string, args ...interface{}) ([]domain.Brand, error) {
-	rows, err := p.DB.QueryContext(ctx, query, args...)
+	return fetchBrands(ctx, p.DB, query, args...)
+}
+
+func fetchBrands(ctx context.Context, q queryer, query string, args ...interface{}) ([]domain.Brand, error) {
+	rows, err := q.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -37,6 +50,9 @@ func (p *postgresBrandRepo) fetch(ctx context.Context, query string, args ...int
 			&t.IsActive,
 			&t.CreatedAt,
 			&t.UpdatedAt,
+			&t.DeletedAt,
+			&t.MergedInto,
+			&t.Version,
 		)
 		if err != nil {
 			return nil, err
@@ -47,34 +63,55 @@ func (p *postgresBrandRepo) fetch(ctx context.Context, query string, args ...int
 	return result, nil
 }
 
-func (p *postgresBrandRepo) Fetch(ctx context.Context, cursor string, num int64) ([]domain.Brand, string, error) {
-	query := `SELECT id, name, slug, logo_url, is_active, created_at, updated_at 
-			  FROM brands WHERE created_at > $1 ORDER BY created_at LIMIT $2`
+func (p *postgresBrandRepo) Fetch(ctx context.Context, filter domain.BrandFilter, cursor string, num int64) ([]domain.Brand, domain.PageInfo, error) {
+	col, ok := brandSorts[filter.Sort.Field]
+	if !ok {
+		return nil, domain.PageInfo{}, fmt.Errorf("%w: cannot sort brands by %q", domain.ErrBadParamInput, filter.Sort.Field)
+	}
+
+	c, err := decodeCursor(cursor, filter.Sort)
+	if err != nil {
+		return nil, domain.PageInfo{}, err
+	}
 
-	decodedCursor, err := time.Parse(time.RFC3339, cursor)
-	if err != nil && cursor != "" {
-		return nil, "", domain.ErrBadParamInput
+	var where conditions
+	if !filter.IncludeDeleted {
+		where.add("deleted_at IS NULL")
 	}
-	if cursor == "" {
-		decodedCursor = time.Time{}
+	if filter.IsActive != nil {
+		where.add("is_active = ?", *filter.IsActive)
+	}
+	if filter.Query != "" {
+		where.addSearch(filter.Query)
+	}
+	if filter.CategoryIDs != nil {
+		where.add("EXISTS (SELECT 1 FROM brand_categories bc WHERE bc.brand_id = brands.id AND bc.category_id = ANY(?))", pq.Array(filter.CategoryIDs))
 	}
 
-	res, err := p.fetch(ctx, query, decodedCursor, num)
+	var total int64
+	err = p.DB.QueryRowContext(ctx, `SELECT COUNT(*) FROM brands WHERE `+where.sql(), where.args...).Scan(&total)
 	if err != nil {
-		return nil, "", err
+		return nil, domain.PageInfo{}, err
 	}
 
-	nextCursor := ""
-	if len(res) > 0 {
-		nextCursor = res[len(res)-1].CreatedAt.Format(time.RFC3339)
+	orderBy := addKeyset(&where, col, c, filter.Sort.Desc)
+	query := `SELECT ` + brandColumns + `
+			  FROM brands WHERE ` + where.sql() + `
+			  ORDER BY ` + orderBy + ` LIMIT ` + where.arg(num+1)
+
+	res, err := p.fetch(ctx, query, where.args...)
+	if err != nil {
+		return nil, domain.PageInfo{}, err
 	}
 
-	return res, nextCursor, nil
+	list, info := paginate(res, num, c, filter.Sort, func(t domain.Brand) (string, string) { return col.value(t), t.ID })
+	info.Total = total
+	return list, info, nil
 }
 
 func (p *postgresBrandRepo) GetByID(ctx context.Context, id string) (domain.Brand, error) {
-	query := `SELECT id, name, slug, logo_url, is_active, created_at, updated_at
-			  FROM brands WHERE id = $1`
+	query := `SELECT ` + brandColumns + `
+			  FROM brands WHERE id = $1 AND deleted_at IS NULL`
 
 	list, err := p.fetch(ctx, query, id)
 	if err != nil {
@@ -89,8 +126,8 @@ func (p *postgresBrandRepo) GetByID(ctx context.Context, id string) (domain.Bran
 }
 
 func (p *postgresBrandRepo) GetBySlug(ctx context.Context, slug string) (domain.Brand, error) {
-	query := `SELECT id, name, slug, logo_url, is_active, created_at, updated_at
-			  FROM brands WHERE slug = $1`
+	query := `SELECT ` + brandColumns + `
+			  FROM brands WHERE slug = $1 AND deleted_at IS NULL`
 
 	list, err := p.fetch(ctx, query, slug)
 	if err != nil {
@@ -104,61 +141,278 @@ func (p *postgresBrandRepo) GetBySlug(ctx context.Context, slug string) (domain.
 	return domain.Brand{}, domain.ErrNotFound
 }
 
+func (p *postgresBrandRepo) BatchGet(ctx context.Context, ids []string) ([]domain.Brand, error) {
+	query := `SELECT ` + brandColumns + `
+			  FROM brands WHERE id = ANY($1) AND deleted_at IS NULL`
+
+	return p.fetch(ctx, query, pq.Array(ids))
+}
+
+func (p *postgresBrandRepo) GetByFormerSlug(ctx context.Context, slug string) (domain.Brand, error) {
+	query := `SELECT ` + brandColumns + `
+			  FROM brands WHERE id = (SELECT brand_id FROM brand_slug_history WHERE slug = $1) AND deleted_at IS NULL`
+
+	list, err := p.fetch(ctx, query, slug)
+	if err != nil {
+		return domain.Brand{}, err
+	}
+
+	if len(list) > 0 {
+		return list[0], nil
+	}
+
+	return domain.Brand{}, domain.ErrNotFound
+}
+
+func (p *postgresBrandRepo) SlugExists(ctx context.Context, slug string) (bool, error) {
+	var exists bool
+	err := p.DB.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM brands WHERE slug = $1)
+			  OR EXISTS (SELECT 1 FROM brand_slug_history WHERE slug = $1)`, slug).Scan(&exists)
+	return exists, err
+}
+
+// snapshotBrand reads brand id as it stands inside tx, deleted or not. The
+// map is empty when there is no such brand.
+func snapshotBrand(ctx context.Context, tx *sql.Tx, id string) (map[string]domain.Brand, error) {
+	list, err := fetchBrands(ctx, tx, `SELECT `+brandColumns+` FROM brands WHERE id = $1`, id)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := make(map[string]domain.Brand, len(list))
+	for _, b := range list {
+		snapshots[b.ID] = b
+	}
+	return snapshots, nil
+}
+
+// emit records a revision and queues an event of type t for brand id,
+// comparing before with the brand as it stands inside tx now.
+func (p *postgresBrandRepo) emit(ctx context.Context, tx *sql.Tx, t domain.EventType, before map[string]domain.Brand, id string) error {
+	after, err := snapshotBrand(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+
+	return recordChanges(ctx, tx, t, domain.AggregateBrand, []string{id}, before, after)
+}
+
 func (p *postgresBrandRepo) Store(ctx context.Context, b *domain.Brand) error {
-	query := `INSERT INTO brands (id, name, slug, logo_url, is_active, created_at, updated_at)
-			  VALUES ($1, $2, $3, $4, $5, $6, $7)`
-	
-	stmt, err := p.DB.PrepareContext(ctx, query)
+	tx, err := p.DB.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
+	defer tx.Rollback()
 
-	_, err = stmt.ExecContext(ctx, b.ID, b.Name, b.Slug, b.LogoURL, b.IsActive, b.CreatedAt, b.UpdatedAt)
+	query := `INSERT INTO brands (id, name, slug, logo_url, is_active, created_at, updated_at)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING version`
+
+	err = tx.QueryRowContext(ctx, query, b.ID, b.Name, b.Slug, b.LogoURL, b.IsActive, b.CreatedAt, b.UpdatedAt).Scan(&b.Version)
 	if err != nil {
+		return translateError(err)
+	}
+
+	if err = p.emit(ctx, tx, domain.EventBrandCreated, nil, b.ID); err != nil {
 		return err
 	}
 
-	return nil
+	return tx.Commit()
 }
 
 func (p *postgresBrandRepo) Update(ctx context.Context, b *domain.Brand) error {
-	query := `UPDATE brands SET name=$2, slug=$3, logo_url=$4, is_active=$5, updated_at=$6
-			  WHERE id=$1`
+	tx, err := p.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var (
+		oldSlug string
+		version int64
+	)
+	err = tx.QueryRowContext(ctx, `SELECT slug, version FROM brands WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`, b.ID).Scan(&oldSlug, &version)
+	if err == sql.ErrNoRows {
+		return domain.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if err = checkVersion(b.Version, version); err != nil {
+		return err
+	}
 
-	stmt, err := p.DB.PrepareContext(ctx, query)
+	before, err := snapshotBrand(ctx, tx, b.ID)
 	if err != nil {
 		return err
 	}
 
-	_, err = stmt.ExecContext(ctx, b.ID, b.Name, b.Slug, b.LogoURL, b.IsActive, b.UpdatedAt)
+	query := `UPDATE brands SET name=$2, slug=$3, logo_url=$4, is_active=$5, updated_at=$6, version=version+1
+			  WHERE id=$1 RETURNING version`
+
+	err = tx.QueryRowContext(ctx, query, b.ID, b.Name, b.Slug, b.LogoURL, b.IsActive, b.UpdatedAt).Scan(&b.Version)
 	if err != nil {
+		return translateError(err)
+	}
+
+	if oldSlug != b.Slug {
+		if err = brandSlugHistory.record(ctx, tx, b.ID, oldSlug, b.Slug); err != nil {
+			return err
+		}
+	}
+
+	if err = p.emit(ctx, tx, domain.EventBrandUpdated, before, b.ID); err != nil {
 		return err
 	}
 
-	return nil
+	return tx.Commit()
 }
 
-func (p *postgresBrandRepo) Delete(ctx context.Context, id string) error {
-	query := `DELETE FROM brands WHERE id = $1`
+func (p *postgresBrandRepo) Delete(ctx context.Context, id string, version int64) error {
+	return p.setDeleted(ctx, id, version, true)
+}
 
-	stmt, err := p.DB.PrepareContext(ctx, query)
+func (p *postgresBrandRepo) Restore(ctx context.Context, id string) error {
+	return p.setDeleted(ctx, id, 0, false)
+}
+
+// setDeleted soft-deletes or restores brand id, checking version as Update does.
+func (p *postgresBrandRepo) setDeleted(ctx context.Context, id string, version int64, deleted bool) error {
+	state, t := "IS NULL", domain.EventBrandDeleted
+	if !deleted {
+		state, t = "IS NOT NULL", domain.EventBrandRestored
+	}
+
+	tx, err := p.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var (
+		current    int64
+		mergedInto *string
+	)
+	err = tx.QueryRowContext(ctx, `SELECT version, merged_into FROM brands WHERE id = $1 AND deleted_at `+state+` FOR UPDATE`, id).Scan(&current, &mergedInto)
+	if err == sql.ErrNoRows {
+		return domain.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if err = checkVersion(version, current); err != nil {
+		return err
+	}
+	if mergedInto != nil {
+		return fmt.Errorf("%w: brand was merged into %q", domain.ErrConflict, *mergedInto)
+	}
+
+	before, err := snapshotBrand(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var deletedAt *time.Time
+	if deleted {
+		deletedAt = &now
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE brands SET deleted_at = $2, updated_at = $3, version = version + 1 WHERE id = $1`, id, deletedAt, now)
+	if err != nil {
+		return err
+	}
+
+	if err = p.emit(ctx, tx, t, before, id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (p *postgresBrandRepo) Merge(ctx context.Context, id, into string, version int64) error {
+	tx, err := p.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Both rows are locked in ID order so that concurrent merges cannot deadlock.
+	locked, err := fetchBrands(ctx, tx, `SELECT `+brandColumns+` FROM brands
+			  WHERE id = ANY($1) AND deleted_at IS NULL ORDER BY id FOR UPDATE`, pq.Array([]string{id, into}))
+	if err != nil {
+		return err
+	}
+	var loser, survivor *domain.Brand
+	for i := range locked {
+		switch locked[i].ID {
+		case id:
+			loser = &locked[i]
+		case into:
+			survivor = &locked[i]
+		}
+	}
+	if loser == nil || survivor == nil {
+		return domain.ErrNotFound
+	}
+	if err = checkVersion(version, loser.Version); err != nil {
+		return err
+	}
+
+	before, err := snapshotBrand(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE brand_categories b SET brand_id = $2
+			  WHERE b.brand_id = $1
+			  AND NOT EXISTS (SELECT 1 FROM brand_categories s WHERE s.brand_id = $2 AND s.category_id = b.category_id)`, id, into)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, `DELETE FROM brand_categories WHERE brand_id = $1`, id)
 	if err != nil {
 		return err
 	}
 
-	res, err := stmt.ExecContext(ctx, id)
+	// Every slug of the loser, current and former, now resolves to the survivor.
+	_, err = tx.ExecContext(ctx, `UPDATE brand_slug_history SET brand_id = $2 WHERE brand_id = $1`, id, into)
 	if err != nil {
 		return err
 	}
+	if err = brandSlugHistory.record(ctx, tx, into, loser.Slug, survivor.Slug); err != nil {
+		return err
+	}
 
-	rowsAfected, err := res.RowsAffected()
+	now := time.Now()
+	_, err = tx.ExecContext(ctx, `UPDATE brands SET deleted_at = $2, updated_at = $2, merged_into = $3, version = version + 1 WHERE id = $1`, id, now, into)
 	if err != nil {
 		return err
 	}
 
-	if rowsAfected != 1 {
-		return fmt.Errorf("weird behavior: total affected: %d", rowsAfected)
+	if err = p.emit(ctx, tx, domain.EventBrandMerged, before, id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (p *postgresBrandRepo) CategoryIDs(ctx context.Context, id string) ([]string, error) {
+	return queryIDs(ctx, p.DB, `SELECT category_id FROM brand_categories WHERE brand_id = $1`, id)
+}
+
+func (p *postgresBrandRepo) AttachCategory(ctx context.Context, id, categoryID string) error {
+	query := `INSERT INTO brand_categories (brand_id, category_id, created_at) VALUES ($1, $2, $3)
+			  ON CONFLICT (brand_id, category_id) DO NOTHING`
+
+	_, err := p.DB.ExecContext(ctx, query, id, categoryID, time.Now())
+	return translateError(err)
+}
+
+func (p *postgresBrandRepo) DetachCategory(ctx context.Context, id, categoryID string) error {
+	res, err := p.DB.ExecContext(ctx, `DELETE FROM brand_categories WHERE brand_id = $1 AND category_id = $2`, id, categoryID)
+	if err != nil {
+		return err
 	}
 
-	return nil
+	return expectOneRow(res)
 }
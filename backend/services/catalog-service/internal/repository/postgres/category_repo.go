@@ -4,11 +4,26 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"slices"
+	"strconv"
 	"time"
 
+	"github.com/lib/pq"
 	"github.com/tokobapak/catalog-service/internal/domain"
 )
 
+// categoryColumns is the column list scanned by fetch, in order.
+const categoryColumns = `id, name, slug, description, parent_id, image_url, icon_url, display_order, is_active, visible_from, visible_until, created_at, updated_at, deleted_at, merged_into, version`
+
+// windowOpen is true for the rows whose visibility window contains $1.
+const windowOpen = `(visible_from IS NULL OR visible_from <= $1) AND (visible_until IS NULL OR visible_until > $1)`
+
+var categorySorts = map[domain.SortField]sortColumn[domain.Category]{
+	domain.SortByCreatedAt:    {"created_at", "timestamptz", func(c domain.Category) string { return c.CreatedAt.Format(time.RFC3339Nano) }},
+	domain.SortByName:         {"name", "text", func(c domain.Category) string { return c.Name }},
+	domain.SortByDisplayOrder: {"display_order", "int", func(c domain.Category) string { return strconv.Itoa(c.DisplayOrder) }},
+}
+
 type postgresCategoryRepo struct {
 	DB *sql.DB
 }
@@ -20,7 +35,11 @@ func NewPostgresCategoryRepository(db *sql.DB) domain.CategoryRepository {
 }
 
 func (p *postgresCategoryRepo) fetch(ctx context.Context, query string, args ...interface{}) ([]domain.Category, error) {
-	rows, err := p.DB.QueryContext(ctx, query, args...)
+	return fetchCategories(ctx, p.DB, query, args...)
+}
+
+func fetchCategories(ctx context.Context, q queryer, query string, args ...interface{}) ([]domain.Category, error) {
+	rows, err := q.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -29,20 +48,7 @@ func (p *postgresCategoryRepo) fetch(ctx context.Context, query string, args ...
 	var result []domain.Category
 	for rows.Next() {
 		var t domain.Category
-		err = rows.Scan(
-			&t.ID,
-			&t.Name,
-			&t.Slug,
-			&t.Description,
-			&t.ParentID,
-			&t.ImageURL,
-			&t.IconURL,
-			&t.DisplayOrder,
-			&t.IsActive,
-			&t.CreatedAt,
-			&t.UpdatedAt,
-		)
-		if err != nil {
+		if err = rows.Scan(categoryFields(&t)...); err != nil {
 			return nil, err
 		}
 		result = append(result, t)
@@ -51,34 +57,83 @@ func (p *postgresCategoryRepo) fetch(ctx context.Context, query string, args ...
 	return result, nil
 }
 
-func (p *postgresCategoryRepo) Fetch(ctx context.Context, cursor string, num int64) ([]domain.Category, string, error) {
-	query := `SELECT id, name, slug, description, parent_id, image_url, icon_url, display_order, is_active, created_at, updated_at 
-			  FROM categories WHERE created_at > $1 ORDER BY created_at LIMIT $2`
+// categoryFields returns the scan destinations for categoryColumns.
+func categoryFields(t *domain.Category) []interface{} {
+	return []interface{}{
+		&t.ID,
+		&t.Name,
+		&t.Slug,
+		&t.Description,
+		&t.ParentID,
+		&t.ImageURL,
+		&t.IconURL,
+		&t.DisplayOrder,
+		&t.IsActive,
+		&t.VisibleFrom,
+		&t.VisibleUntil,
+		&t.CreatedAt,
+		&t.UpdatedAt,
+		&t.DeletedAt,
+		&t.MergedInto,
+		&t.Version,
+	}
+}
+
+func (p *postgresCategoryRepo) Fetch(ctx context.Context, filter domain.CategoryFilter, cursor string, num int64) ([]domain.Category, domain.PageInfo, error) {
+	col, ok := categorySorts[filter.Sort.Field]
+	if !ok {
+		return nil, domain.PageInfo{}, fmt.Errorf("%w: cannot sort categories by %q", domain.ErrBadParamInput, filter.Sort.Field)
+	}
+
+	c, err := decodeCursor(cursor, filter.Sort)
+	if err != nil {
+		return nil, domain.PageInfo{}, err
+	}
 
-	decodedCursor, err := time.Parse(time.RFC3339, cursor)
-	if err != nil && cursor != "" {
-		return nil, "", domain.ErrBadParamInput
+	var where conditions
+	if !filter.IncludeDeleted {
+		where.add("deleted_at IS NULL")
+	}
+	if filter.IsActive != nil {
+		where.add("is_active = ?", *filter.IsActive)
+	}
+	if filter.VisibleAt != nil {
+		where.add("(visible_from IS NULL OR visible_from <= ?)", *filter.VisibleAt)
+		where.add("(visible_until IS NULL OR visible_until > ?)", *filter.VisibleAt)
+	}
+	if filter.RootOnly {
+		where.add("parent_id IS NULL")
+	} else if filter.ParentID != nil {
+		where.add("parent_id = ?", *filter.ParentID)
 	}
-	if cursor == "" {
-		decodedCursor = time.Time{}
+	if filter.Query != "" {
+		where.addSearch(filter.Query)
 	}
 
-	res, err := p.fetch(ctx, query, decodedCursor, num)
+	var total int64
+	err = p.DB.QueryRowContext(ctx, `SELECT COUNT(*) FROM categories WHERE `+where.sql(), where.args...).Scan(&total)
 	if err != nil {
-		return nil, "", err
+		return nil, domain.PageInfo{}, err
 	}
 
-	nextCursor := ""
-	if len(res) > 0 {
-		nextCursor = res[len(res)-1].CreatedAt.Format(time.RFC3339)
+	orderBy := addKeyset(&where, col, c, filter.Sort.Desc)
+	query := `SELECT ` + categoryColumns + `
+			  FROM categories WHERE ` + where.sql() + `
+			  ORDER BY ` + orderBy + ` LIMIT ` + where.arg(num+1)
+
+	res, err := p.fetch(ctx, query, where.args...)
+	if err != nil {
+		return nil, domain.PageInfo{}, err
 	}
 
-	return res, nextCursor, nil
+	list, info := paginate(res, num, c, filter.Sort, func(t domain.Category) (string, string) { return col.value(t), t.ID })
+	info.Total = total
+	return list, info, nil
 }
 
 func (p *postgresCategoryRepo) GetByID(ctx context.Context, id string) (domain.Category, error) {
-	query := `SELECT id, name, slug, description, parent_id, image_url, icon_url, display_order, is_active, created_at, updated_at
-			  FROM categories WHERE id = $1`
+	query := `SELECT ` + categoryColumns + `
+			  FROM categories WHERE id = $1 AND deleted_at IS NULL`
 
 	list, err := p.fetch(ctx, query, id)
 	if err != nil {
@@ -93,8 +148,8 @@ func (p *postgresCategoryRepo) GetByID(ctx context.Context, id string) (domain.C
 }
 
 func (p *postgresCategoryRepo) GetBySlug(ctx context.Context, slug string) (domain.Category, error) {
-	query := `SELECT id, name, slug, description, parent_id, image_url, icon_url, display_order, is_active, created_at, updated_at
-			  FROM categories WHERE slug = $1`
+	query := `SELECT ` + categoryColumns + `
+			  FROM categories WHERE slug = $1 AND deleted_at IS NULL`
 
 	list, err := p.fetch(ctx, query, slug)
 	if err != nil {
@@ -108,77 +163,983 @@ func (p *postgresCategoryRepo) GetBySlug(ctx context.Context, slug string) (doma
 	return domain.Category{}, domain.ErrNotFound
 }
 
+func (p *postgresCategoryRepo) BatchGet(ctx context.Context, ids []string) ([]domain.Category, error) {
+	query := `SELECT ` + categoryColumns + `
+			  FROM categories WHERE id = ANY($1) AND deleted_at IS NULL`
+
+	return p.fetch(ctx, query, pq.Array(ids))
+}
+
+func (p *postgresCategoryRepo) GetByFormerSlug(ctx context.Context, slug string) (domain.Category, error) {
+	query := `SELECT ` + categoryColumns + `
+			  FROM categories WHERE id = (SELECT category_id FROM category_slug_history WHERE slug = $1) AND deleted_at IS NULL`
+
+	list, err := p.fetch(ctx, query, slug)
+	if err != nil {
+		return domain.Category{}, err
+	}
+
+	if len(list) > 0 {
+		return list[0], nil
+	}
+
+	return domain.Category{}, domain.ErrNotFound
+}
+
+func (p *postgresCategoryRepo) SlugExists(ctx context.Context, slug string) (bool, error) {
+	var exists bool
+	err := p.DB.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM categories WHERE slug = $1)
+			  OR EXISTS (SELECT 1 FROM category_slug_history WHERE slug = $1)`, slug).Scan(&exists)
+	return exists, err
+}
+
 func (p *postgresCategoryRepo) GetByParentID(ctx context.Context, parentID *string) ([]domain.Category, error) {
 	var query string
 	var args []interface{}
 
 	if parentID == nil {
-		query = `SELECT id, name, slug, description, parent_id, image_url, icon_url, display_order, is_active, created_at, updated_at
-				 FROM categories WHERE parent_id IS NULL ORDER BY display_order ASC`
+		query = `SELECT ` + categoryColumns + `
+				 FROM categories WHERE parent_id IS NULL AND deleted_at IS NULL ORDER BY display_order ASC`
 	} else {
-		query = `SELECT id, name, slug, description, parent_id, image_url, icon_url, display_order, is_active, created_at, updated_at
-				 FROM categories WHERE parent_id = $1 ORDER BY display_order ASC`
+		query = `SELECT ` + categoryColumns + `
+				 FROM categories WHERE parent_id = $1 AND deleted_at IS NULL ORDER BY display_order ASC`
 		args = append(args, parentID)
 	}
 
 	return p.fetch(ctx, query, args...)
 }
 
-func (p *postgresCategoryRepo) Store(ctx context.Context, c *domain.Category) error {
-	query := `INSERT INTO categories (id, name, slug, description, parent_id, image_url, icon_url, display_order, is_active, created_at, updated_at)
-			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
-	
-	stmt, err := p.DB.PrepareContext(ctx, query)
+func (p *postgresCategoryRepo) GetTree(ctx context.Context, maxDepth int, activeOnly bool) ([]domain.Category, error) {
+	// Inactive categories are pruned together with their subtree when activeOnly is set,
+	// so a hidden parent never leaks visible children into the menu.
+	query := `WITH RECURSIVE tree AS (
+				SELECT categories.*, 1 AS depth
+				FROM categories
+				WHERE parent_id IS NULL AND deleted_at IS NULL AND ($2 = FALSE OR is_active)
+				UNION ALL
+				SELECT c.*, t.depth + 1
+				FROM categories c
+				JOIN tree t ON c.parent_id = t.id
+				WHERE ($1 = 0 OR t.depth < $1) AND c.deleted_at IS NULL AND ($2 = FALSE OR c.is_active)
+			  )
+			  SELECT ` + categoryColumns + `
+			  FROM tree ORDER BY depth, display_order, name`
+
+	return p.fetch(ctx, query, maxDepth, activeOnly)
+}
+
+func (p *postgresCategoryRepo) GetAncestors(ctx context.Context, id string) ([]domain.Category, error) {
+	// The visited path guards against looping forever on rows that already form a cycle.
+	query := `WITH RECURSIVE chain AS (
+				SELECT categories.*, 0 AS lvl, ARRAY[id]::VARCHAR[] AS path
+				FROM categories
+				WHERE id = $1 AND deleted_at IS NULL
+				UNION ALL
+				SELECT c.*, ch.lvl + 1, ch.path || c.id
+				FROM categories c
+				JOIN chain ch ON c.id = ch.parent_id
+				WHERE NOT c.id = ANY(ch.path)
+			  )
+			  SELECT ` + categoryColumns + `
+			  FROM chain ORDER BY lvl DESC`
+
+	list, err := p.fetch(ctx, query, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(list) == 0 {
+		return nil, domain.ErrNotFound
+	}
+
+	return list, nil
+}
+
+func (p *postgresCategoryRepo) GetDescendants(ctx context.Context, id string) ([]domain.Category, error) {
+	query := `WITH RECURSIVE subtree AS (
+				SELECT categories.*, 1 AS depth, ARRAY[$1::VARCHAR, id]::VARCHAR[] AS path
+				FROM categories
+				WHERE parent_id = $1 AND deleted_at IS NULL
+				UNION ALL
+				SELECT c.*, s.depth + 1, s.path || c.id
+				FROM categories c
+				JOIN subtree s ON c.parent_id = s.id
+				WHERE NOT c.id = ANY(s.path) AND c.deleted_at IS NULL
+			  )
+			  SELECT ` + categoryColumns + `
+			  FROM subtree ORDER BY depth, display_order, name`
+
+	return p.fetch(ctx, query, id)
+}
+
+func (p *postgresCategoryRepo) Move(ctx context.Context, id string, parentID *string, position int) error {
+	tx, err := p.DB.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
+	defer tx.Rollback()
 
-	_, err = stmt.ExecContext(ctx, c.ID, c.Name, c.Slug, c.Description, c.ParentID, c.ImageURL, c.IconURL, c.DisplayOrder, c.IsActive, c.CreatedAt, c.UpdatedAt)
+	var oldParentID *string
+	err = tx.QueryRowContext(ctx, `SELECT parent_id FROM categories WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`, id).Scan(&oldParentID)
+	if err == sql.ErrNoRows {
+		return domain.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	before, err := snapshotCategories(ctx, tx, []string{id})
+	if err != nil {
+		return err
+	}
+
+	if err = checkPlacement(ctx, tx, id, parentID); err != nil {
+		return err
+	}
+
+	siblings, err := lockSiblingIDs(ctx, tx, parentID, id)
+	if err != nil {
+		return err
+	}
+
+	ordered := insertAt(siblings, id, position)
+
+	_, err = tx.ExecContext(ctx, `UPDATE categories SET parent_id = $2, updated_at = $3, version = version + 1 WHERE id = $1`, id, parentID, time.Now())
 	if err != nil {
 		return err
 	}
 
+	if err = resequence(ctx, tx, ordered); err != nil {
+		return err
+	}
+
+	// Close the gap left behind among the previous siblings.
+	if !sameParent(oldParentID, parentID) {
+		previous, err := lockSiblingIDs(ctx, tx, oldParentID, id)
+		if err != nil {
+			return err
+		}
+		if err = resequence(ctx, tx, previous); err != nil {
+			return err
+		}
+	}
+
+	if err = p.emit(ctx, tx, domain.EventCategoryUpdated, before, id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (p *postgresCategoryRepo) Reorder(ctx context.Context, parentID *string, ids []string) error {
+	tx, err := p.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	current, err := lockSiblingIDs(ctx, tx, parentID, "")
+	if err != nil {
+		return err
+	}
+
+	if err = checkOrder(current, ids); err != nil {
+		return err
+	}
+
+	before, err := snapshotCategories(ctx, tx, ids)
+	if err != nil {
+		return err
+	}
+
+	if err = resequence(ctx, tx, ids); err != nil {
+		return err
+	}
+
+	if err = p.emit(ctx, tx, domain.EventCategoryUpdated, before, ids...); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// siblingsAfterDelete returns the siblings of a category deleted under
+// policy in their new order: the reparent policy appends its children, and
+// the block policy fails with ErrCategoryHasChildren when there are any.
+func siblingsAfterDelete(policy domain.DeletePolicy, siblings, children []string) ([]string, error) {
+	switch policy {
+	case domain.DeletePolicyCascade:
+		return siblings, nil
+	case domain.DeletePolicyReparent:
+		return append(siblings, children...), nil
+	default:
+		if len(children) > 0 {
+			return nil, domain.ErrCategoryHasChildren
+		}
+		return siblings, nil
+	}
+}
+
+// checkRestore fails with ErrConflict when a deleted category cannot come
+// back: a merged duplicate lives on in the category it was merged into, and
+// a category under a deleted parent would be orphaned.
+func checkRestore(parentID, mergedInto *string, parentDeleted bool) error {
+	if mergedInto != nil {
+		return fmt.Errorf("%w: category was merged into %q", domain.ErrConflict, *mergedInto)
+	}
+	if parentDeleted {
+		return fmt.Errorf("%w: parent category %q is deleted, restore it first", domain.ErrConflict, *parentID)
+	}
 	return nil
 }
 
-func (p *postgresCategoryRepo) Update(ctx context.Context, c *domain.Category) error {
-	query := `UPDATE categories SET name=$2, slug=$3, description=$4, parent_id=$5, image_url=$6, icon_url=$7, display_order=$8, is_active=$9, updated_at=$10
-			  WHERE id=$1`
+// archivedWith returns id and those of its soft-deleted descendants that were
+// archived together with it: a cascade gives a whole subtree the same
+// deleted_at, and a descendant deleted on its own keeps its own subtree
+// deleted. descendants must be ordered by depth.
+func archivedWith(id string, deletedAt time.Time, descendants []domain.Category) []string {
+	restored := []string{id}
+	in := map[string]bool{id: true}
+	for _, d := range descendants {
+		if d.ParentID == nil || !in[*d.ParentID] || d.DeletedAt == nil || !d.DeletedAt.Equal(deletedAt) {
+			continue
+		}
+		in[d.ID] = true
+		restored = append(restored, d.ID)
+	}
+	return restored
+}
+
+// checkOrder fails with ErrOrderMismatch unless ids, which the caller has
+// checked for duplicates, are exactly the current children.
+func checkOrder(current, ids []string) error {
+	if len(current) != len(ids) {
+		return domain.ErrOrderMismatch
+	}
+	children := make(map[string]bool, len(current))
+	for _, id := range current {
+		children[id] = true
+	}
+	for _, id := range ids {
+		if !children[id] {
+			return domain.ErrOrderMismatch
+		}
+	}
+	return nil
+}
+
+// checkPlacement re-checks inside tx that category id can sit under parentID
+// without a cycle or exceeding MaxCategoryDepth, so that two concurrent
+// writes cannot each pass validation and together loop or deepen the tree.
+// Locking the chain above the new parent holds off moves of any of its rows
+// until tx commits.
+func checkPlacement(ctx context.Context, tx *sql.Tx, id string, parentID *string) error {
+	if parentID == nil {
+		return nil
+	}
 
-	stmt, err := p.DB.PrepareContext(ctx, query)
+	chain, err := lockChain(ctx, tx, *parentID)
+	if err != nil {
+		return err
+	}
+	height, err := subtreeHeight(ctx, tx, id)
 	if err != nil {
 		return err
 	}
+	return placementError(chain, id, height)
+}
 
-	_, err = stmt.ExecContext(ctx, c.ID, c.Name, c.Slug, c.Description, c.ParentID, c.ImageURL, c.IconURL, c.DisplayOrder, c.IsActive, c.UpdatedAt)
+// checkMergePlacement is checkPlacement for merging id into into: the
+// children of id move under into, one level higher than they were.
+func checkMergePlacement(ctx context.Context, tx *sql.Tx, id, into string) error {
+	chain, err := lockChain(ctx, tx, into)
+	if err != nil {
+		return err
+	}
+	height, err := subtreeHeight(ctx, tx, id)
 	if err != nil {
 		return err
 	}
+	return placementError(chain, id, height-1)
+}
 
+// placementError reports whether a subtree of the given height rooted at id
+// can hang below chain, the IDs of its new parent and that parent's
+// ancestors.
+func placementError(chain []string, id string, height int) error {
+	if slices.Contains(chain, id) {
+		return domain.ErrCategoryCycle
+	}
+	if len(chain)+height > domain.MaxCategoryDepth {
+		return domain.ErrMaxDepthExceeded
+	}
 	return nil
 }
 
-func (p *postgresCategoryRepo) Delete(ctx context.Context, id string) error {
-	query := `DELETE FROM categories WHERE id = $1`
+// insertAt returns siblings with id inserted at position, or appended when
+// position is negative or past the end.
+func insertAt(siblings []string, id string, position int) []string {
+	if position < 0 || position > len(siblings) {
+		position = len(siblings)
+	}
+	ordered := make([]string, 0, len(siblings)+1)
+	ordered = append(ordered, siblings[:position]...)
+	ordered = append(ordered, id)
+	return append(ordered, siblings[position:]...)
+}
+
+// chainQuery selects id $1 and its ancestors.
+const chainQuery = `WITH RECURSIVE chain AS (
+				SELECT id, parent_id, ARRAY[id]::VARCHAR[] AS path FROM categories WHERE id = $1
+				UNION ALL
+				SELECT c.id, c.parent_id, ch.path || c.id
+				FROM categories c JOIN chain ch ON c.id = ch.parent_id
+				WHERE NOT c.id = ANY(ch.path)
+			  )`
+
+// lockChain returns the IDs of id and its ancestors, locking those rows
+// against concurrent moves for the rest of the transaction. The chain is read
+// again once locked, so that it reflects moves committed while waiting.
+func lockChain(ctx context.Context, tx *sql.Tx, id string) ([]string, error) {
+	_, err := queryIDs(ctx, tx, chainQuery+`
+			  SELECT c.id FROM categories c JOIN chain ch ON ch.id = c.id
+			  ORDER BY c.id FOR SHARE OF c`, id)
+	if err != nil {
+		return nil, err
+	}
 
-	stmt, err := p.DB.PrepareContext(ctx, query)
+	return queryIDs(ctx, tx, chainQuery+` SELECT id FROM chain`, id)
+}
+
+// subtreeHeight returns the number of levels in the live subtree rooted at
+// id, counting id itself.
+func subtreeHeight(ctx context.Context, tx *sql.Tx, id string) (int, error) {
+	var height int
+	err := tx.QueryRowContext(ctx, `WITH RECURSIVE subtree AS (
+				SELECT id, 1 AS level, ARRAY[id]::VARCHAR[] AS path FROM categories WHERE id = $1
+				UNION ALL
+				SELECT c.id, s.level + 1, s.path || c.id
+				FROM categories c JOIN subtree s ON c.parent_id = s.id
+				WHERE NOT c.id = ANY(s.path) AND c.deleted_at IS NULL
+			  )
+			  SELECT MAX(level) FROM subtree`, id).Scan(&height)
+	return height, err
+}
+
+// lockSiblingIDs returns the IDs of the children of parentID in display order,
+// excluding the given id, and locks those rows for the rest of the transaction.
+func lockSiblingIDs(ctx context.Context, tx *sql.Tx, parentID *string, excludeID string) ([]string, error) {
+	return queryIDs(ctx, tx, `SELECT id FROM categories
+			  WHERE parent_id IS NOT DISTINCT FROM $1 AND id <> $2 AND deleted_at IS NULL
+			  ORDER BY display_order, name FOR UPDATE`, parentID, excludeID)
+}
+
+// queryIDs runs a query selecting or returning a single id column.
+func queryIDs(ctx context.Context, q queryer, query string, args ...interface{}) ([]string, error) {
+	rows, err := q.QueryContext(ctx, query, args...)
 	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// resequence assigns display_order 0..n-1 following the order of ids, bumping
+// the version of the rows whose position changed.
+func resequence(ctx context.Context, tx *sql.Tx, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	_, err := tx.ExecContext(ctx, `UPDATE categories c SET display_order = o.ord - 1, version = c.version + 1
+			  FROM unnest($1::VARCHAR[]) WITH ORDINALITY AS o(id, ord)
+			  WHERE c.id = o.id AND c.display_order <> o.ord - 1`, pq.Array(ids))
+	return err
+}
+
+// checkVersion compares the version a client expects with the current one;
+// an expected version of 0 skips the check.
+func checkVersion(expected, current int64) error {
+	if expected != 0 && expected != current {
+		return fmt.Errorf("%w: expected version %d, current version is %d", domain.ErrVersionMismatch, expected, current)
+	}
+	return nil
+}
+
+func sameParent(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// snapshotCategories reads the categories in ids as they stand inside tx,
+// deleted or not, keyed by ID.
+func snapshotCategories(ctx context.Context, tx *sql.Tx, ids []string) (map[string]domain.Category, error) {
+	list, err := fetchCategories(ctx, tx, `SELECT `+categoryColumns+` FROM categories WHERE id = ANY($1)`, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := make(map[string]domain.Category, len(list))
+	for _, c := range list {
+		snapshots[c.ID] = c
+	}
+	return snapshots, nil
+}
+
+// emit records a revision and queues an event of type t for each of ids,
+// comparing before with the categories as they stand inside tx now.
+func (p *postgresCategoryRepo) emit(ctx context.Context, tx *sql.Tx, t domain.EventType, before map[string]domain.Category, ids ...string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	after, err := snapshotCategories(ctx, tx, ids)
+	if err != nil {
+		return err
+	}
+
+	return recordChanges(ctx, tx, t, domain.AggregateCategory, ids, before, after)
+}
+
+func (p *postgresCategoryRepo) Store(ctx context.Context, c *domain.Category) error {
+	tx, err := p.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err = p.insert(ctx, tx, c); err != nil {
 		return err
 	}
 
-	res, err := stmt.ExecContext(ctx, id)
+	return tx.Commit()
+}
+
+// insert writes a new category inside tx and records its creation.
+func (p *postgresCategoryRepo) insert(ctx context.Context, tx *sql.Tx, c *domain.Category) error {
+	// window_open starts out as of the write, so the scheduler only announces
+	// the window opening or closing later on.
+	query := `INSERT INTO categories (id, name, slug, description, parent_id, image_url, icon_url, display_order, is_active, visible_from, visible_until, window_open, created_at, updated_at)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14) RETURNING version`
+
+	err := tx.QueryRowContext(ctx, query, c.ID, c.Name, c.Slug, c.Description, c.ParentID, c.ImageURL, c.IconURL, c.DisplayOrder, c.IsActive, c.VisibleFrom, c.VisibleUntil, c.VisibleAt(c.UpdatedAt), c.CreatedAt, c.UpdatedAt).Scan(&c.Version)
+	if err != nil {
+		return translateError(err)
+	}
+
+	return p.emit(ctx, tx, domain.EventCategoryCreated, nil, c.ID)
+}
+
+func (p *postgresCategoryRepo) Update(ctx context.Context, c *domain.Category) error {
+	tx, err := p.DB.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
+	defer tx.Rollback()
 
-	rowsAfected, err := res.RowsAffected()
+	if err = p.update(ctx, tx, c); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// update overwrites a category inside tx after checking its version, and
+// records the change.
+func (p *postgresCategoryRepo) update(ctx context.Context, tx *sql.Tx, c *domain.Category) error {
+	var (
+		oldSlug     string
+		oldParentID *string
+		version     int64
+	)
+	err := tx.QueryRowContext(ctx, `SELECT slug, parent_id, version FROM categories WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`, c.ID).Scan(&oldSlug, &oldParentID, &version)
+	if err == sql.ErrNoRows {
+		return domain.ErrNotFound
+	}
 	if err != nil {
 		return err
 	}
+	if err = checkVersion(c.Version, version); err != nil {
+		return err
+	}
 
-	if rowsAfected != 1 {
-		return fmt.Errorf("weird behavior: total affected: %d", rowsAfected)
+	// A new parent is checked and sequenced as Move does; the category takes
+	// the position of its DisplayOrder among its new siblings.
+	var ordered, previous []string
+	reparented := !sameParent(oldParentID, c.ParentID)
+	if reparented {
+		if err = checkPlacement(ctx, tx, c.ID, c.ParentID); err != nil {
+			return err
+		}
+
+		siblings, err := lockSiblingIDs(ctx, tx, c.ParentID, c.ID)
+		if err != nil {
+			return err
+		}
+		ordered = insertAt(siblings, c.ID, c.DisplayOrder)
+		c.DisplayOrder = slices.Index(ordered, c.ID)
+
+		if previous, err = lockSiblingIDs(ctx, tx, oldParentID, c.ID); err != nil {
+			return err
+		}
 	}
 
-	return nil
+	before, err := snapshotCategories(ctx, tx, []string{c.ID})
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE categories SET name=$2, slug=$3, description=$4, parent_id=$5, image_url=$6, icon_url=$7, display_order=$8, is_active=$9,
+			  visible_from=$10, visible_until=$11, window_open=$12, updated_at=$13, version=version+1
+			  WHERE id=$1 RETURNING version`
+
+	err = tx.QueryRowContext(ctx, query, c.ID, c.Name, c.Slug, c.Description, c.ParentID, c.ImageURL, c.IconURL, c.DisplayOrder, c.IsActive,
+		c.VisibleFrom, c.VisibleUntil, c.VisibleAt(c.UpdatedAt), c.UpdatedAt).Scan(&c.Version)
+	if err != nil {
+		return translateError(err)
+	}
+
+	if oldSlug != c.Slug {
+		if err = categorySlugHistory.record(ctx, tx, c.ID, oldSlug, c.Slug); err != nil {
+			return err
+		}
+	}
+
+	if reparented {
+		if err = resequence(ctx, tx, ordered); err != nil {
+			return err
+		}
+		if err = resequence(ctx, tx, previous); err != nil {
+			return err
+		}
+	}
+
+	return p.emit(ctx, tx, domain.EventCategoryUpdated, before, c.ID)
+}
+
+func (p *postgresCategoryRepo) Import(ctx context.Context, creates, updates []domain.Category) error {
+	tx, err := p.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for i := range creates {
+		if err = p.insert(ctx, tx, &creates[i]); err != nil {
+			return fmt.Errorf("create %s: %w", creates[i].Slug, err)
+		}
+	}
+
+	for i := range updates {
+		if err = p.update(ctx, tx, &updates[i]); err != nil {
+			return fmt.Errorf("update %s: %w", updates[i].Slug, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (p *postgresCategoryRepo) Export(ctx context.Context, fn func(domain.Category, string) error) error {
+	// Ordering by path in byte order puts every parent before its children.
+	query := `WITH RECURSIVE tree AS (
+				SELECT categories.*, slug::TEXT AS path
+				FROM categories
+				WHERE parent_id IS NULL AND deleted_at IS NULL
+				UNION ALL
+				SELECT c.*, t.path || '/' || c.slug
+				FROM categories c
+				JOIN tree t ON c.parent_id = t.id
+				WHERE c.deleted_at IS NULL
+			  )
+			  SELECT ` + categoryColumns + `, path
+			  FROM tree ORDER BY path COLLATE "C"`
+
+	rows, err := p.DB.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			c    domain.Category
+			path string
+		)
+		if err = rows.Scan(append(categoryFields(&c), &path)...); err != nil {
+			return err
+		}
+		if err = fn(c, path); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func (p *postgresCategoryRepo) SyncVisibility(ctx context.Context, now time.Time) (int, error) {
+	tx, err := p.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	// Rows without a window always have window_open set, so only windowed rows
+	// can be out of date. SKIP LOCKED leaves rows being written, or synced by
+	// another instance, to the next run.
+	rows, err := tx.QueryContext(ctx, `SELECT id, window_open FROM categories
+			  WHERE (visible_from IS NOT NULL OR visible_until IS NOT NULL) AND deleted_at IS NULL
+			  AND window_open <> (`+windowOpen+`)
+			  FOR UPDATE SKIP LOCKED`, now)
+	if err != nil {
+		return 0, err
+	}
+
+	var opened, closed []string
+	for rows.Next() {
+		var (
+			id      string
+			wasOpen bool
+		)
+		if err = rows.Scan(&id, &wasOpen); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		if wasOpen {
+			closed = append(closed, id)
+		} else {
+			opened = append(opened, id)
+		}
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return 0, err
+	}
+
+	ids := append(opened, closed...)
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	before, err := snapshotCategories(ctx, tx, ids)
+	if err != nil {
+		return 0, err
+	}
+
+	// The category itself is unchanged, so its version is left alone.
+	if _, err = tx.ExecContext(ctx, `UPDATE categories SET window_open = NOT window_open WHERE id = ANY($1)`, pq.Array(ids)); err != nil {
+		return 0, err
+	}
+
+	if err = p.emit(ctx, tx, domain.EventCategoryWindowOpened, before, opened...); err != nil {
+		return 0, err
+	}
+	if err = p.emit(ctx, tx, domain.EventCategoryWindowClosed, before, closed...); err != nil {
+		return 0, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, err
+	}
+	return len(ids), nil
+}
+
+func (p *postgresCategoryRepo) Delete(ctx context.Context, id string, version int64, policy domain.DeletePolicy) error {
+	tx, err := p.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var (
+		parentID *string
+		current  int64
+	)
+	err = tx.QueryRowContext(ctx, `SELECT parent_id, version FROM categories WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`, id).Scan(&parentID, &current)
+	if err == sql.ErrNoRows {
+		return domain.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if err = checkVersion(version, current); err != nil {
+		return err
+	}
+
+	children, err := lockSiblingIDs(ctx, tx, &id, "")
+	if err != nil {
+		return err
+	}
+
+	siblings, err := lockSiblingIDs(ctx, tx, parentID, id)
+	if err != nil {
+		return err
+	}
+	if siblings, err = siblingsAfterDelete(policy, siblings, children); err != nil {
+		return err
+	}
+
+	var archived, reparented []string
+	if policy == domain.DeletePolicyCascade {
+		archived, err = queryIDs(ctx, tx, `WITH RECURSIVE subtree AS (
+				SELECT id, ARRAY[id]::VARCHAR[] AS path FROM categories WHERE parent_id = $1 AND deleted_at IS NULL
+				UNION ALL
+				SELECT c.id, s.path || c.id
+				FROM categories c JOIN subtree s ON c.parent_id = s.id
+				WHERE NOT c.id = ANY(s.path) AND c.deleted_at IS NULL
+			  )
+			  SELECT id FROM subtree`, id)
+		if err != nil {
+			return err
+		}
+	}
+
+	before, err := snapshotCategories(ctx, tx, append(append([]string{id}, children...), archived...))
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	switch policy {
+	case domain.DeletePolicyCascade:
+		// Every row of the subtree shares the same deleted_at so Restore can bring it back as a unit.
+		_, err = tx.ExecContext(ctx, `UPDATE categories SET deleted_at = $2, updated_at = $2, version = version + 1 WHERE id = ANY($1)`, pq.Array(archived), now)
+		if err != nil {
+			return err
+		}
+	case domain.DeletePolicyReparent:
+		if len(children) > 0 {
+			_, err = tx.ExecContext(ctx, `UPDATE categories SET parent_id = $2, updated_at = $3, version = version + 1 WHERE id = ANY($1)`, pq.Array(children), parentID, now)
+			if err != nil {
+				return err
+			}
+		}
+		reparented = children
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE categories SET deleted_at = $2, updated_at = $2, version = version + 1 WHERE id = $1`, id, now)
+	if err != nil {
+		return err
+	}
+
+	if err = resequence(ctx, tx, siblings); err != nil {
+		return err
+	}
+
+	if err = p.emit(ctx, tx, domain.EventCategoryUpdated, before, reparented...); err != nil {
+		return err
+	}
+	if err = p.emit(ctx, tx, domain.EventCategoryDeleted, before, append(archived, id)...); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (p *postgresCategoryRepo) Restore(ctx context.Context, id string) error {
+	tx, err := p.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var (
+		parentID   *string
+		deletedAt  time.Time
+		mergedInto *string
+	)
+	err = tx.QueryRowContext(ctx, `SELECT parent_id, deleted_at, merged_into FROM categories WHERE id = $1 AND deleted_at IS NOT NULL FOR UPDATE`, id).Scan(&parentID, &deletedAt, &mergedInto)
+	if err == sql.ErrNoRows {
+		return domain.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	var parentDeleted bool
+	if parentID != nil && mergedInto == nil {
+		err = tx.QueryRowContext(ctx, `SELECT deleted_at IS NOT NULL FROM categories WHERE id = $1`, *parentID).Scan(&parentDeleted)
+		if err != nil {
+			return err
+		}
+	}
+	if err = checkRestore(parentID, mergedInto, parentDeleted); err != nil {
+		return err
+	}
+
+	siblings, err := lockSiblingIDs(ctx, tx, parentID, id)
+	if err != nil {
+		return err
+	}
+
+	// Bring back the descendants that were archived together with the category.
+	archived, err := fetchCategories(ctx, tx, `WITH RECURSIVE subtree AS (
+				SELECT categories.*, 1 AS depth, ARRAY[$1::VARCHAR, id]::VARCHAR[] AS path
+				FROM categories
+				WHERE parent_id = $1 AND deleted_at IS NOT NULL
+				UNION ALL
+				SELECT c.*, s.depth + 1, s.path || c.id
+				FROM categories c
+				JOIN subtree s ON c.parent_id = s.id
+				WHERE NOT c.id = ANY(s.path) AND c.deleted_at IS NOT NULL
+			  )
+			  SELECT `+categoryColumns+`
+			  FROM subtree ORDER BY depth, id`, id)
+	if err != nil {
+		return err
+	}
+	restored := archivedWith(id, deletedAt, archived)
+
+	before, err := snapshotCategories(ctx, tx, restored)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE categories SET deleted_at = NULL, updated_at = $2, version = version + 1 WHERE id = ANY($1)`, pq.Array(restored), time.Now())
+	if err != nil {
+		return err
+	}
+
+	if err = resequence(ctx, tx, append(siblings, id)); err != nil {
+		return err
+	}
+
+	if err = p.emit(ctx, tx, domain.EventCategoryRestored, before, restored...); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// categoryLinks are the tables associating other rows with a category, each
+// with the column that completes its key, which Merge hands over to the
+// survivor. Fee rules stay behind: their periods would overlap those of the
+// survivor.
+var categoryLinks = []struct{ table, keyColumn string }{
+	{"category_attributes", "attribute_id"},
+	{"brand_categories", "brand_id"},
+	{"category_taxonomies", "taxonomy"},
+}
+
+func (p *postgresCategoryRepo) Merge(ctx context.Context, id, into string, version int64) error {
+	tx, err := p.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Both rows are locked in ID order so that concurrent merges cannot deadlock.
+	locked, err := fetchCategories(ctx, tx, `SELECT `+categoryColumns+` FROM categories
+			  WHERE id = ANY($1) AND deleted_at IS NULL ORDER BY id FOR UPDATE`, pq.Array([]string{id, into}))
+	if err != nil {
+		return err
+	}
+	var loser, survivor *domain.Category
+	for i := range locked {
+		switch locked[i].ID {
+		case id:
+			loser = &locked[i]
+		case into:
+			survivor = &locked[i]
+		}
+	}
+	if loser == nil || survivor == nil {
+		return domain.ErrNotFound
+	}
+	if err = checkVersion(version, loser.Version); err != nil {
+		return err
+	}
+
+	if err = checkMergePlacement(ctx, tx, id, into); err != nil {
+		return err
+	}
+
+	children, err := lockSiblingIDs(ctx, tx, &id, "")
+	if err != nil {
+		return err
+	}
+
+	// The children of the loser follow those of the survivor, which no longer
+	// counts the loser among them.
+	ordered, err := lockSiblingIDs(ctx, tx, &into, id)
+	if err != nil {
+		return err
+	}
+	ordered = append(ordered, children...)
+
+	var siblings []string
+	if !sameParent(loser.ParentID, &into) {
+		siblings, err = lockSiblingIDs(ctx, tx, loser.ParentID, id)
+		if err != nil {
+			return err
+		}
+	}
+
+	before, err := snapshotCategories(ctx, tx, append([]string{id}, children...))
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if len(children) > 0 {
+		_, err = tx.ExecContext(ctx, `UPDATE categories SET parent_id = $2, updated_at = $3, version = version + 1 WHERE id = ANY($1)`, pq.Array(children), into, now)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Links the survivor already has win over those of the loser.
+	for _, l := range categoryLinks {
+		_, err = tx.ExecContext(ctx, `UPDATE `+l.table+` t SET category_id = $2
+				  WHERE t.category_id = $1
+				  AND NOT EXISTS (SELECT 1 FROM `+l.table+` s WHERE s.category_id = $2 AND s.`+l.keyColumn+` = t.`+l.keyColumn+`)`, id, into)
+		if err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, `DELETE FROM `+l.table+` WHERE category_id = $1`, id)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Every slug of the loser, current and former, now resolves to the survivor.
+	_, err = tx.ExecContext(ctx, `UPDATE category_slug_history SET category_id = $2 WHERE category_id = $1`, id, into)
+	if err != nil {
+		return err
+	}
+	if err = categorySlugHistory.record(ctx, tx, into, loser.Slug, survivor.Slug); err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE categories SET deleted_at = $2, updated_at = $2, merged_into = $3, version = version + 1 WHERE id = $1`, id, now, into)
+	if err != nil {
+		return err
+	}
+
+	if err = resequence(ctx, tx, ordered); err != nil {
+		return err
+	}
+	if err = resequence(ctx, tx, siblings); err != nil {
+		return err
+	}
+
+	if err = p.emit(ctx, tx, domain.EventCategoryUpdated, before, children...); err != nil {
+		return err
+	}
+	if err = p.emit(ctx, tx, domain.EventCategoryMerged, before, id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
@@ -0,0 +1,139 @@
+package postgres
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tokobapak/catalog-service/internal/domain"
+)
+
+func TestInsertAt(t *testing.T) {
+	cases := []struct {
+		position int
+		want     string
+	}{
+		{0, "x,a,b"},
+		{1, "a,x,b"},
+		{2, "a,b,x"},
+		{3, "a,b,x"},
+		{-1, "a,b,x"},
+	}
+	for _, tc := range cases {
+		got := strings.Join(insertAt([]string{"a", "b"}, "x", tc.position), ",")
+		if got != tc.want {
+			t.Errorf("position %d: expected %s, got %s", tc.position, tc.want, got)
+		}
+	}
+}
+
+func TestPlacementError(t *testing.T) {
+	cases := []struct {
+		name   string
+		chain  []string
+		height int
+		err    error
+	}{
+		{"under a root", []string{"elektronik"}, 2, nil},
+		{"at the depth limit", []string{"l1", "l2", "l3"}, 2, nil},
+		{"past the depth limit", []string{"l1", "l2", "l3", "l4"}, 2, domain.ErrMaxDepthExceeded},
+		// A merge moves the children of the loser, one level less than its subtree.
+		{"merged at the depth limit", []string{"l1", "l2", "l3", "l4"}, 1, nil},
+		{"under its own descendant", []string{"elektronik", "hp", "android"}, 1, domain.ErrCategoryCycle},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := placementError(tc.chain, "hp", tc.height); !errors.Is(err, tc.err) {
+				t.Fatalf("expected %v, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestCheckOrder(t *testing.T) {
+	current := []string{"handphone", "laptop", "kamera"}
+
+	cases := []struct {
+		name string
+		ids  []string
+		err  error
+	}{
+		{"reordered", []string{"kamera", "handphone", "laptop"}, nil},
+		{"incomplete", []string{"kamera", "handphone"}, domain.ErrOrderMismatch},
+		{"stale", []string{"kamera", "handphone", "tablet"}, domain.ErrOrderMismatch},
+		{"extra", []string{"kamera", "handphone", "laptop", "tablet"}, domain.ErrOrderMismatch},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := checkOrder(current, tc.ids); !errors.Is(err, tc.err) {
+				t.Fatalf("expected %v, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestSiblingsAfterDelete(t *testing.T) {
+	siblings, children := []string{"laptop", "kamera"}, []string{"android", "ios"}
+
+	cases := []struct {
+		policy   domain.DeletePolicy
+		children []string
+		want     string
+		err      error
+	}{
+		{domain.DeletePolicyBlock, nil, "laptop,kamera", nil},
+		{domain.DeletePolicyBlock, children, "", domain.ErrCategoryHasChildren},
+		{domain.DeletePolicyCascade, children, "laptop,kamera", nil},
+		{domain.DeletePolicyReparent, children, "laptop,kamera,android,ios", nil},
+	}
+	for _, tc := range cases {
+		got, err := siblingsAfterDelete(tc.policy, slices.Clone(siblings), tc.children)
+		if !errors.Is(err, tc.err) {
+			t.Errorf("%s: expected %v, got %v", tc.policy, tc.err, err)
+			continue
+		}
+		if strings.Join(got, ",") != tc.want {
+			t.Errorf("%s: expected %s, got %v", tc.policy, tc.want, got)
+		}
+	}
+}
+
+func TestCheckRestore(t *testing.T) {
+	parent, into := "elektronik", "handphone"
+
+	if err := checkRestore(&parent, nil, false); err != nil {
+		t.Fatalf("expected a category under a live parent to be restorable, got %v", err)
+	}
+	if err := checkRestore(nil, &into, false); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("expected ErrConflict for a merged category, got %v", err)
+	}
+	if err := checkRestore(&parent, nil, true); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("expected ErrConflict under a deleted parent, got %v", err)
+	}
+}
+
+func TestArchivedWith(t *testing.T) {
+	cascade := time.Date(2025, time.March, 1, 10, 0, 0, 0, time.UTC)
+	earlier := cascade.Add(-time.Hour)
+
+	// handphone was cascaded with elektronik; kamera was deleted on its own
+	// before, so it and its child stay deleted even though the child shares
+	// the cascade's timestamp.
+	descendants := []domain.Category{
+		{ID: "handphone", ParentID: strPtr("elektronik"), DeletedAt: &cascade},
+		{ID: "kamera", ParentID: strPtr("elektronik"), DeletedAt: &earlier},
+		{ID: "android", ParentID: strPtr("handphone"), DeletedAt: &cascade},
+		{ID: "mirrorless", ParentID: strPtr("kamera"), DeletedAt: &cascade},
+	}
+
+	got := strings.Join(archivedWith("elektronik", cascade, descendants), ",")
+	if got != "elektronik,handphone,android" {
+		t.Fatalf("expected only the rows archived with elektronik, got %s", got)
+	}
+}
+
+func strPtr(s string) *string {
+	return &s
+}
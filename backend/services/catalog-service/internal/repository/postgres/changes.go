@@ -0,0 +1,46 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/tokobapak/catalog-service/internal/domain"
+)
+
+// recordChanges writes a revision and queues an event of type t for each of
+// ids as part of tx. before and after map aggregate IDs to their snapshots
+// around the change; IDs missing from after are skipped, and an ID missing
+// from before is recorded as newly created.
+func recordChanges[T any](ctx context.Context, tx *sql.Tx, t domain.EventType, aggregateType string, ids []string, before, after map[string]T) error {
+	now := time.Now()
+	actor := domain.ActorFromContext(ctx)
+	for _, id := range ids {
+		snapshot, ok := after[id]
+		if !ok {
+			continue
+		}
+
+		payload, err := json.Marshal(snapshot)
+		if err != nil {
+			return err
+		}
+
+		var previous []byte
+		if old, ok := before[id]; ok {
+			if previous, err = json.Marshal(old); err != nil {
+				return err
+			}
+		}
+
+		if err = insertRevision(ctx, tx, t.Action(), aggregateType, id, actor, previous, payload, now); err != nil {
+			return err
+		}
+		if err = insertEvent(ctx, tx, t, aggregateType, id, payload, now); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
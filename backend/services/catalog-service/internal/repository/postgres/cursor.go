@@ -0,0 +1,118 @@
+package postgres
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/tokobapak/catalog-service/internal/domain"
+)
+
+// sortColumn maps a domain.SortField onto the column behind it. Cursor values
+// travel as text and are cast back to the column type in SQL.
+type sortColumn[T any] struct {
+	column string
+	cast   string
+	value  func(T) string
+}
+
+// pageCursor is the keyset position a listing resumes from. It is handed to
+// clients as base64 JSON so they treat it as opaque.
+type pageCursor struct {
+	Sort  domain.SortField `json:"s"`
+	Desc  bool             `json:"d,omitempty"`
+	Value string           `json:"v"`
+	ID    string           `json:"i"`
+	// Backward asks for the page before the position rather than after it.
+	Backward bool `json:"b,omitempty"`
+}
+
+func (c pageCursor) encode() string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// decodeCursor parses a cursor issued by encode for the same sort. An empty
+// string is the start of the listing and yields nil.
+func decodeCursor(s string, sort domain.Sort) (*pageCursor, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", domain.ErrBadParamInput)
+	}
+
+	var c pageCursor
+	if err := json.Unmarshal(raw, &c); err != nil || c.ID == "" {
+		return nil, fmt.Errorf("%w: malformed cursor", domain.ErrBadParamInput)
+	}
+	if c.Sort != sort.Field || c.Desc != sort.Desc {
+		return nil, fmt.Errorf("%w: cursor was issued for a different sort", domain.ErrBadParamInput)
+	}
+
+	return &c, nil
+}
+
+// addKeyset restricts where to the rows after (or, for a backward cursor,
+// before) c and returns the matching ORDER BY.
+func addKeyset[T any](where *conditions, col sortColumn[T], c *pageCursor, desc bool) string {
+	// Reading backward walks the listing in reverse; paginate flips it back.
+	if c != nil && c.Backward {
+		desc = !desc
+	}
+
+	op, dir := ">", "ASC"
+	if desc {
+		op, dir = "<", "DESC"
+	}
+
+	if c != nil {
+		where.add(fmt.Sprintf("(%s, id) %s (?::%s, ?)", col.column, op, col.cast), c.Value, c.ID)
+	}
+
+	return fmt.Sprintf("%s %s, id %s", col.column, dir, dir)
+}
+
+// paginate turns rows read with addKeyset and a limit of num+1 into a page in
+// listing order plus the cursors around it.
+func paginate[T any](rows []T, num int64, c *pageCursor, sort domain.Sort, key func(T) (value, id string)) ([]T, domain.PageInfo) {
+	more := int64(len(rows)) > num
+	if more {
+		rows = rows[:num]
+	}
+
+	backward := c != nil && c.Backward
+	if backward {
+		for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+			rows[i], rows[j] = rows[j], rows[i]
+		}
+	}
+
+	var info domain.PageInfo
+	if len(rows) == 0 {
+		return rows, info
+	}
+
+	// Going forward there is something behind us whenever we started from a
+	// cursor; going backward we just came from the rows ahead.
+	hasNext, hasPrev := more, c != nil
+	if backward {
+		hasNext, hasPrev = true, more
+	}
+
+	at := func(row T, backward bool) string {
+		value, id := key(row)
+		return pageCursor{Sort: sort.Field, Desc: sort.Desc, Value: value, ID: id, Backward: backward}.encode()
+	}
+	if hasNext {
+		info.NextCursor = at(rows[len(rows)-1], false)
+	}
+	if hasPrev {
+		info.PrevCursor = at(rows[0], true)
+	}
+	info.HasMore = hasNext
+
+	return rows, info
+}
@@ -0,0 +1,91 @@
+package postgres
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/tokobapak/catalog-service/internal/domain"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	byName := domain.Sort{Field: domain.SortByName, Desc: true}
+	issued := pageCursor{Sort: byName.Field, Desc: true, Value: "Apple", ID: "b-1", Backward: true}
+
+	c, err := decodeCursor(issued.encode(), byName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *c != issued {
+		t.Fatalf("cursor did not survive the round trip: %+v", c)
+	}
+
+	if _, err := decodeCursor(issued.encode(), domain.Sort{Field: domain.SortByName}); !errors.Is(err, domain.ErrBadParamInput) {
+		t.Errorf("expected a cursor for another sort to be rejected, got %v", err)
+	}
+
+	for _, bad := range []string{"not base64!", "bm90IGpzb24", "e30"} {
+		if _, err := decodeCursor(bad, byName); !errors.Is(err, domain.ErrBadParamInput) {
+			t.Errorf("decodeCursor(%q): expected ErrBadParamInput, got %v", bad, err)
+		}
+	}
+}
+
+func TestAddKeyset(t *testing.T) {
+	col := brandSorts[domain.SortByName]
+
+	var where conditions
+	where.add("deleted_at IS NULL")
+	orderBy := addKeyset(&where, col, &pageCursor{Value: "Apple", ID: "b-1", Backward: true}, false)
+
+	if want := "deleted_at IS NULL AND (name, id) < ($1::text, $2)"; where.sql() != want {
+		t.Errorf("expected %q, got %q", want, where.sql())
+	}
+	if orderBy != "name DESC, id DESC" {
+		t.Errorf("expected a backward read to reverse the order, got %q", orderBy)
+	}
+	if len(where.args) != 2 || where.args[0] != "Apple" || where.args[1] != "b-1" {
+		t.Errorf("unexpected args %v", where.args)
+	}
+}
+
+func TestAddSearchEscapesWildcards(t *testing.T) {
+	var where conditions
+	where.addSearch("50%_off")
+
+	if want := "(name ILIKE $1 OR slug ILIKE $2 OR name % $3)"; where.sql() != want {
+		t.Errorf("expected %q, got %q", want, where.sql())
+	}
+	if where.args[0] != `50\%\_off%` {
+		t.Errorf("expected wildcards to be escaped, got %v", where.args[0])
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	byName := domain.Sort{Field: domain.SortByName}
+	// Rows sharing a sort value must still be told apart by id.
+	rows := []domain.Brand{
+		{ID: "a", Name: "Apple"},
+		{ID: "b", Name: "Apple"},
+		{ID: "c", Name: "Apple"},
+	}
+	key := func(b domain.Brand) (string, string) { return b.Name, b.ID }
+
+	page, info := paginate(rows, 2, nil, byName, key)
+	if len(page) != 2 || !info.HasMore || info.NextCursor == "" || info.PrevCursor != "" {
+		t.Fatalf("unexpected first page: %+v %+v", page, info)
+	}
+	next, err := decodeCursor(info.NextCursor, byName)
+	if err != nil || next.ID != "b" || next.Backward {
+		t.Fatalf("expected the next cursor to resume after b, got %+v, %v", next, err)
+	}
+
+	// A backward read comes back in reverse and is flipped into listing order.
+	back := []domain.Brand{rows[1], rows[0]}
+	page, info = paginate(back, 2, &pageCursor{Sort: byName.Field, ID: "c", Backward: true}, byName, key)
+	if len(page) != 2 || page[0].ID != "a" || page[1].ID != "b" {
+		t.Fatalf("expected a, b in listing order, got %+v", page)
+	}
+	if !info.HasMore || info.PrevCursor != "" {
+		t.Fatalf("expected a next cursor and no previous page, got %+v", info)
+	}
+}
@@ -0,0 +1,49 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"github.com/tokobapak/catalog-service/internal/domain"
+)
+
+// SQLSTATE codes of the constraint violations a write can run into.
+const (
+	pqStringTooLong       = "22001"
+	pqForeignKeyViolation = "23503"
+	pqUniqueViolation     = "23505"
+	pqCheckViolation      = "23514"
+	pqExclusionViolation  = "23P01"
+)
+
+// translateError turns constraint violations into domain errors, so a write
+// that lost a race against another one is reported to the client instead of
+// surfacing as an internal error. Other errors are returned unchanged.
+func translateError(err error) error {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return err
+	}
+
+	switch pqErr.Code {
+	case pqUniqueViolation, pqExclusionViolation:
+		return fmt.Errorf("%w: %s", domain.ErrConflict, violationDetail(pqErr))
+	case pqForeignKeyViolation:
+		return fmt.Errorf("%w: %s", domain.ErrNotFound, violationDetail(pqErr))
+	case pqStringTooLong, pqCheckViolation:
+		return fmt.Errorf("%w: %s", domain.ErrBadParamInput, pqErr.Message)
+	default:
+		return err
+	}
+}
+
+// violationDetail prefers the detail of a violation, which names the
+// offending key, over its generic message.
+func violationDetail(e *pq.Error) string {
+	if e.Detail != "" {
+		return e.Detail
+	}
+	return e.Message
+}
@@ -0,0 +1,34 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/lib/pq"
+
+	"github.com/tokobapak/catalog-service/internal/domain"
+)
+
+func TestTranslateError(t *testing.T) {
+	cases := []struct {
+		err  error
+		want error
+	}{
+		{&pq.Error{Code: pqUniqueViolation, Detail: `Key (slug)=(elektronik) already exists.`}, domain.ErrConflict},
+		{&pq.Error{Code: pqExclusionViolation, Message: `conflicting key value violates exclusion constraint "fee_rules_no_overlap"`}, domain.ErrConflict},
+		{fmt.Errorf("insert: %w", &pq.Error{Code: pqForeignKeyViolation}), domain.ErrNotFound},
+		{&pq.Error{Code: pqStringTooLong}, domain.ErrBadParamInput},
+	}
+
+	for _, tc := range cases {
+		if got := translateError(tc.err); !errors.Is(got, tc.want) {
+			t.Errorf("%v: expected %v, got %v", tc.err, tc.want, got)
+		}
+	}
+
+	other := &pq.Error{Code: "40001"}
+	if got := translateError(other); got != error(other) {
+		t.Errorf("expected other errors unchanged, got %v", got)
+	}
+}
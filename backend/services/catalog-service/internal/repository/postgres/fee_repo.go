@@ -0,0 +1,111 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/tokobapak/catalog-service/internal/domain"
+)
+
+const feeRuleColumns = `id, category_id, commission_basis_points, fixed_fee, effective_from, effective_to, created_at, updated_at`
+
+type postgresFeeRuleRepo struct {
+	DB *sql.DB
+}
+
+func NewPostgresFeeRuleRepository(db *sql.DB) domain.FeeRuleRepository {
+	return &postgresFeeRuleRepo{
+		DB: db,
+	}
+}
+
+func (p *postgresFeeRuleRepo) fetch(ctx context.Context, query string, args ...interface{}) ([]domain.FeeRule, error) {
+	rows, err := p.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []domain.FeeRule
+	for rows.Next() {
+		var t domain.FeeRule
+		err = rows.Scan(
+			&t.ID,
+			&t.CategoryID,
+			&t.CommissionBasisPoints,
+			&t.FixedFee,
+			&t.EffectiveFrom,
+			&t.EffectiveTo,
+			&t.CreatedAt,
+			&t.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, t)
+	}
+
+	return result, rows.Err()
+}
+
+func (p *postgresFeeRuleRepo) FetchByCategory(ctx context.Context, categoryID string) ([]domain.FeeRule, error) {
+	query := `SELECT ` + feeRuleColumns + `
+			  FROM fee_rules WHERE category_id = $1
+			  ORDER BY effective_from`
+
+	return p.fetch(ctx, query, categoryID)
+}
+
+func (p *postgresFeeRuleRepo) GetInForce(ctx context.Context, categoryIDs []string, t time.Time) ([]domain.FeeRule, error) {
+	query := `SELECT ` + feeRuleColumns + `
+			  FROM fee_rules
+			  WHERE category_id = ANY($1) AND effective_from <= $2 AND (effective_to IS NULL OR effective_to > $2)`
+
+	return p.fetch(ctx, query, pq.Array(categoryIDs), t)
+}
+
+func (p *postgresFeeRuleRepo) GetByID(ctx context.Context, id string) (domain.FeeRule, error) {
+	query := `SELECT ` + feeRuleColumns + ` FROM fee_rules WHERE id = $1`
+
+	list, err := p.fetch(ctx, query, id)
+	if err != nil {
+		return domain.FeeRule{}, err
+	}
+
+	if len(list) > 0 {
+		return list[0], nil
+	}
+
+	return domain.FeeRule{}, domain.ErrNotFound
+}
+
+func (p *postgresFeeRuleRepo) Store(ctx context.Context, r *domain.FeeRule) error {
+	query := `INSERT INTO fee_rules (` + feeRuleColumns + `)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+	_, err := p.DB.ExecContext(ctx, query, r.ID, r.CategoryID, r.CommissionBasisPoints, r.FixedFee, r.EffectiveFrom, r.EffectiveTo, r.CreatedAt, r.UpdatedAt)
+	return translateError(err)
+}
+
+func (p *postgresFeeRuleRepo) Update(ctx context.Context, r *domain.FeeRule) error {
+	query := `UPDATE fee_rules SET commission_basis_points=$2, fixed_fee=$3, effective_from=$4, effective_to=$5, updated_at=$6
+			  WHERE id=$1`
+
+	res, err := p.DB.ExecContext(ctx, query, r.ID, r.CommissionBasisPoints, r.FixedFee, r.EffectiveFrom, r.EffectiveTo, r.UpdatedAt)
+	if err != nil {
+		return translateError(err)
+	}
+
+	return expectOneRow(res)
+}
+
+func (p *postgresFeeRuleRepo) Delete(ctx context.Context, id string) error {
+	res, err := p.DB.ExecContext(ctx, `DELETE FROM fee_rules WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	return expectOneRow(res)
+}
@@ -0,0 +1,167 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/tokobapak/catalog-service/internal/domain"
+)
+
+// queryer is satisfied by both *sql.DB and *sql.Tx, so the fetch helpers can
+// read inside a write transaction.
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// cleanupBatchSize bounds the rows DeletePublished removes per statement, so
+// that a large backlog does not hold locks for long.
+const cleanupBatchSize = 1000
+
+type postgresOutboxRepo struct {
+	DB          *sql.DB
+	maxAttempts int
+}
+
+// NewPostgresOutboxRepository dead-letters an event once publishing it has
+// failed maxAttempts times.
+func NewPostgresOutboxRepository(db *sql.DB, maxAttempts int) domain.OutboxRepository {
+	return &postgresOutboxRepo{
+		DB:          db,
+		maxAttempts: maxAttempts,
+	}
+}
+
+func (p *postgresOutboxRepo) PublishPending(ctx context.Context, limit int, publish func(context.Context, []domain.Event) error) (int, error) {
+	tx, err := p.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	// SKIP LOCKED lets several relays share the table; the NOT EXISTS holds back
+	// an event until every earlier event of its aggregate is out or dead.
+	rows, err := tx.QueryContext(ctx, `SELECT o.id, o.event_id, o.event_type, o.aggregate_type, o.aggregate_id, o.payload, o.created_at, o.attempts
+			  FROM outbox_events o
+			  WHERE o.published_at IS NULL AND o.dead_at IS NULL AND NOT EXISTS (
+				SELECT 1 FROM outbox_events e
+				WHERE e.aggregate_type = o.aggregate_type AND e.aggregate_id = o.aggregate_id
+				  AND e.published_at IS NULL AND e.dead_at IS NULL AND e.id < o.id
+			  )
+			  ORDER BY o.id LIMIT $1 FOR UPDATE OF o SKIP LOCKED`, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		seqs     []int64
+		events   []domain.Event
+		attempts []int
+	)
+	for rows.Next() {
+		var (
+			seq int64
+			e   domain.Event
+			n   int
+		)
+		if err = rows.Scan(&seq, &e.ID, &e.Type, &e.AggregateType, &e.AggregateID, &e.Payload, &e.OccurredAt, &n); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		seqs = append(seqs, seq)
+		events = append(events, e)
+		attempts = append(attempts, n)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return 0, err
+	}
+
+	if len(events) == 0 {
+		return 0, nil
+	}
+
+	pick := nextBatch(attempts)
+	seqs, events = pickIndexes(seqs, pick), pickIndexes(events, pick)
+
+	if perr := publish(ctx, events); perr != nil {
+		// Events out of attempts are dead-lettered, which releases the later
+		// events of their aggregate.
+		_, err = tx.ExecContext(ctx, `UPDATE outbox_events SET attempts = attempts + 1, last_error = $2,
+				  dead_at = CASE WHEN attempts + 1 >= $3 THEN $4::TIMESTAMPTZ END
+				  WHERE id = ANY($1)`, pq.Array(seqs), perr.Error(), p.maxAttempts, time.Now())
+		if err != nil {
+			return 0, err
+		}
+		if err = tx.Commit(); err != nil {
+			return 0, err
+		}
+		return 0, perr
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE outbox_events SET published_at = $2, attempts = attempts + 1, last_error = NULL WHERE id = ANY($1)`, pq.Array(seqs), time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return len(events), nil
+}
+
+func (p *postgresOutboxRepo) DeletePublished(ctx context.Context, before time.Time) (int, error) {
+	deleted := 0
+	for {
+		res, err := p.DB.ExecContext(ctx, `DELETE FROM outbox_events WHERE id IN (
+				  SELECT id FROM outbox_events WHERE published_at < $1 LIMIT $2
+				)`, before, cleanupBatchSize)
+		if err != nil {
+			return deleted, err
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return deleted, err
+		}
+		deleted += int(n)
+		if n < cleanupBatchSize {
+			return deleted, nil
+		}
+	}
+}
+
+// nextBatch returns the indexes of the fetched events to publish, given how
+// often publishing each has failed. Events that never failed go out
+// together; otherwise the oldest failed one goes alone, so that an event the
+// broker keeps rejecting fails on its own instead of with every batch it is
+// part of.
+func nextBatch(attempts []int) []int {
+	var fresh []int
+	for i, n := range attempts {
+		if n == 0 {
+			fresh = append(fresh, i)
+		}
+	}
+	if len(fresh) > 0 {
+		return fresh
+	}
+	return []int{0}
+}
+
+func pickIndexes[T any](list []T, indexes []int) []T {
+	picked := make([]T, 0, len(indexes))
+	for _, i := range indexes {
+		picked = append(picked, list[i])
+	}
+	return picked
+}
+
+// insertEvent queues an event in the outbox as part of tx.
+func insertEvent(ctx context.Context, tx *sql.Tx, t domain.EventType, aggregateType, aggregateID string, payload []byte, at time.Time) error {
+	_, err := tx.ExecContext(ctx, `INSERT INTO outbox_events (event_id, event_type, aggregate_type, aggregate_id, payload, created_at)
+			  VALUES ($1, $2, $3, $4, $5, $6)`, uuid.New().String(), t, aggregateType, aggregateID, payload, at)
+	return err
+}
@@ -0,0 +1,24 @@
+package postgres
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestNextBatch(t *testing.T) {
+	cases := []struct {
+		attempts []int
+		want     string
+	}{
+		{[]int{0, 0, 0}, "[0 1 2]"},
+		// A failed event waits while fresh ones go out together.
+		{[]int{2, 0, 0}, "[1 2]"},
+		// Then the oldest failed one goes alone.
+		{[]int{2, 1, 3}, "[0]"},
+	}
+	for _, tc := range cases {
+		if got := fmt.Sprint(nextBatch(tc.attempts)); got != tc.want {
+			t.Errorf("%v: expected %s, got %s", tc.attempts, tc.want, got)
+		}
+	}
+}
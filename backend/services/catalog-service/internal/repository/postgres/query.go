@@ -0,0 +1,49 @@
+package postgres
+
+import (
+	"strconv"
+	"strings"
+)
+
+// conditions accumulates AND-ed WHERE clauses and their arguments. Clauses are
+// written with ? placeholders, which are numbered as the clauses are added so
+// user input only ever reaches the database as a bound argument.
+type conditions struct {
+	clauses []string
+	args    []interface{}
+}
+
+func (c *conditions) add(clause string, args ...interface{}) {
+	var b strings.Builder
+	next := 0
+	for _, r := range clause {
+		if r == '?' && next < len(args) {
+			b.WriteString(c.arg(args[next]))
+			next++
+			continue
+		}
+		b.WriteRune(r)
+	}
+	c.clauses = append(c.clauses, b.String())
+}
+
+// arg binds v and returns its placeholder.
+func (c *conditions) arg(v interface{}) string {
+	c.args = append(c.args, v)
+	return "$" + strconv.Itoa(len(c.args))
+}
+
+func (c *conditions) sql() string {
+	if len(c.clauses) == 0 {
+		return "TRUE"
+	}
+	return strings.Join(c.clauses, " AND ")
+}
+
+// addSearch matches name and slug by prefix and name by trigram similarity.
+func (c *conditions) addSearch(q string) {
+	prefix := likeEscaper.Replace(q) + "%"
+	c.add("(name ILIKE ? OR slug ILIKE ? OR name % ?)", prefix, prefix, q)
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
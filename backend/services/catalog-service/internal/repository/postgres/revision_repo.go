@@ -0,0 +1,174 @@
+package postgres
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/tokobapak/catalog-service/internal/domain"
+)
+
+// revisionColumns is the column list scanned by fetch, in order.
+const revisionColumns = `id, entity_type, entity_id, action, actor, before_state, after_state, diff, created_at`
+
+// revisionSort lists revisions newest first; it is the only order offered.
+var (
+	revisionSort   = domain.Sort{Field: domain.SortByCreatedAt, Desc: true}
+	revisionColumn = sortColumn[domain.Revision]{"created_at", "timestamptz", func(r domain.Revision) string { return r.CreatedAt.Format(time.RFC3339Nano) }}
+)
+
+type postgresRevisionRepo struct {
+	DB *sql.DB
+}
+
+func NewPostgresRevisionRepository(db *sql.DB) domain.RevisionRepository {
+	return &postgresRevisionRepo{
+		DB: db,
+	}
+}
+
+func (p *postgresRevisionRepo) fetch(ctx context.Context, query string, args ...interface{}) ([]domain.Revision, error) {
+	rows, err := p.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []domain.Revision
+	for rows.Next() {
+		var (
+			t            domain.Revision
+			before, diff []byte
+		)
+		err = rows.Scan(
+			&t.ID,
+			&t.EntityType,
+			&t.EntityID,
+			&t.Action,
+			&t.Actor,
+			&before,
+			&t.After,
+			&diff,
+			&t.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		t.Before = before
+		if err = json.Unmarshal(diff, &t.Diff); err != nil {
+			return nil, err
+		}
+		result = append(result, t)
+	}
+
+	return result, rows.Err()
+}
+
+func (p *postgresRevisionRepo) Fetch(ctx context.Context, entityType, entityID, cursor string, num int64) ([]domain.Revision, domain.PageInfo, error) {
+	c, err := decodeCursor(cursor, revisionSort)
+	if err != nil {
+		return nil, domain.PageInfo{}, err
+	}
+
+	var where conditions
+	where.add("entity_type = ?", entityType)
+	where.add("entity_id = ?", entityID)
+
+	var total int64
+	err = p.DB.QueryRowContext(ctx, `SELECT COUNT(*) FROM revisions WHERE `+where.sql(), where.args...).Scan(&total)
+	if err != nil {
+		return nil, domain.PageInfo{}, err
+	}
+
+	orderBy := addKeyset(&where, revisionColumn, c, revisionSort.Desc)
+	query := `SELECT ` + revisionColumns + `
+			  FROM revisions WHERE ` + where.sql() + `
+			  ORDER BY ` + orderBy + ` LIMIT ` + where.arg(num+1)
+
+	res, err := p.fetch(ctx, query, where.args...)
+	if err != nil {
+		return nil, domain.PageInfo{}, err
+	}
+
+	list, info := paginate(res, num, c, revisionSort, func(r domain.Revision) (string, string) {
+		return revisionColumn.value(r), strconv.FormatInt(r.ID, 10)
+	})
+	info.Total = total
+	return list, info, nil
+}
+
+func (p *postgresRevisionRepo) GetByID(ctx context.Context, id int64) (domain.Revision, error) {
+	query := `SELECT ` + revisionColumns + `
+			  FROM revisions WHERE id = $1`
+
+	list, err := p.fetch(ctx, query, id)
+	if err != nil {
+		return domain.Revision{}, err
+	}
+
+	if len(list) > 0 {
+		return list[0], nil
+	}
+
+	return domain.Revision{}, domain.ErrNotFound
+}
+
+// insertRevision records a revision as part of tx. before is nil for a creation.
+func insertRevision(ctx context.Context, tx *sql.Tx, action, entityType, entityID, actor string, before, after []byte, at time.Time) error {
+	diff, err := diffSnapshots(before, after)
+	if err != nil {
+		return err
+	}
+
+	raw, err := json.Marshal(diff)
+	if err != nil {
+		return err
+	}
+
+	var previous interface{}
+	if before != nil {
+		previous = before
+	}
+
+	_, err = tx.ExecContext(ctx, `INSERT INTO revisions (entity_type, entity_id, action, actor, before_state, after_state, diff, created_at)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`, entityType, entityID, action, actor, previous, after, raw, at)
+	return err
+}
+
+// diffSnapshots compares two JSON objects field by field and returns the
+// fields whose values differ. A nil before counts as an empty object.
+func diffSnapshots(before, after []byte) (map[string]domain.FieldChange, error) {
+	var old, cur map[string]json.RawMessage
+	if before != nil {
+		if err := json.Unmarshal(before, &old); err != nil {
+			return nil, err
+		}
+	}
+	if err := json.Unmarshal(after, &cur); err != nil {
+		return nil, err
+	}
+
+	diff := make(map[string]domain.FieldChange)
+	for k, from := range old {
+		if to := cur[k]; !bytes.Equal(from, to) {
+			diff[k] = domain.FieldChange{From: from, To: nullIfEmpty(to)}
+		}
+	}
+	for k, to := range cur {
+		if _, ok := old[k]; !ok {
+			diff[k] = domain.FieldChange{From: nullIfEmpty(nil), To: to}
+		}
+	}
+
+	return diff, nil
+}
+
+func nullIfEmpty(raw json.RawMessage) json.RawMessage {
+	if raw == nil {
+		return json.RawMessage("null")
+	}
+	return raw
+}
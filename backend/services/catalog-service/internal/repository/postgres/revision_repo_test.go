@@ -0,0 +1,41 @@
+package postgres
+
+import (
+	"testing"
+)
+
+func TestDiffSnapshots(t *testing.T) {
+	before := []byte(`{"id":"1","name":"Elektronik","isActive":true,"imageUrl":"a.png"}`)
+	after := []byte(`{"id":"1","name":"Elektronik & Gadget","isActive":true,"iconUrl":"b.svg"}`)
+
+	diff, err := diffSnapshots(before, after)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string][2]string{
+		"name":     {`"Elektronik"`, `"Elektronik & Gadget"`},
+		"imageUrl": {`"a.png"`, `null`},
+		"iconUrl":  {`null`, `"b.svg"`},
+	}
+	if len(diff) != len(want) {
+		t.Fatalf("expected %d changed fields, got %+v", len(want), diff)
+	}
+	for field, w := range want {
+		got, ok := diff[field]
+		if !ok || string(got.From) != w[0] || string(got.To) != w[1] {
+			t.Fatalf("%s: expected %s -> %s, got %s -> %s", field, w[0], w[1], got.From, got.To)
+		}
+	}
+}
+
+func TestDiffSnapshotsOfCreation(t *testing.T) {
+	diff, err := diffSnapshots(nil, []byte(`{"id":"1","name":"Elektronik"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(diff) != 2 || string(diff["name"].From) != "null" || string(diff["name"].To) != `"Elektronik"` {
+		t.Fatalf("expected every field to be new, got %+v", diff)
+	}
+}
@@ -0,0 +1,33 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// slugHistory names the table keeping the former slugs of one entity type and
+// the column pointing back at the owner.
+type slugHistory struct {
+	table       string
+	ownerColumn string
+}
+
+var (
+	categorySlugHistory = slugHistory{table: "category_slug_history", ownerColumn: "category_id"}
+	brandSlugHistory    = slugHistory{table: "brand_slug_history", ownerColumn: "brand_id"}
+)
+
+// record keeps oldSlug resolving to id after a rename. newSlug is dropped from
+// the history first, in case id is taking back one of its own former slugs.
+func (h slugHistory) record(ctx context.Context, tx *sql.Tx, id, oldSlug, newSlug string) error {
+	_, err := tx.ExecContext(ctx, `DELETE FROM `+h.table+` WHERE slug = $1 AND `+h.ownerColumn+` = $2`, newSlug, id)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `INSERT INTO `+h.table+` (slug, `+h.ownerColumn+`, created_at) VALUES ($1, $2, $3)
+			  ON CONFLICT (slug) DO UPDATE SET `+h.ownerColumn+` = EXCLUDED.`+h.ownerColumn+`, created_at = EXCLUDED.created_at`,
+		oldSlug, id, time.Now())
+	return err
+}
@@ -0,0 +1,73 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/lib/pq"
+	"github.com/tokobapak/catalog-service/internal/domain"
+)
+
+type postgresTaxonomyRepo struct {
+	DB *sql.DB
+}
+
+func NewPostgresTaxonomyRepository(db *sql.DB) domain.TaxonomyRepository {
+	return &postgresTaxonomyRepo{
+		DB: db,
+	}
+}
+
+func (p *postgresTaxonomyRepo) fetch(ctx context.Context, query string, args ...interface{}) ([]domain.TaxonomyMapping, error) {
+	rows, err := p.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []domain.TaxonomyMapping
+	for rows.Next() {
+		var t domain.TaxonomyMapping
+		if err = rows.Scan(&t.CategoryID, &t.Taxonomy, &t.ExternalID, &t.UpdatedAt); err != nil {
+			return nil, err
+		}
+		result = append(result, t)
+	}
+
+	return result, rows.Err()
+}
+
+func (p *postgresTaxonomyRepo) GetByCategoryIDs(ctx context.Context, categoryIDs []string) ([]domain.TaxonomyMapping, error) {
+	query := `SELECT category_id, taxonomy, external_id, updated_at
+			  FROM category_taxonomies
+			  WHERE category_id = ANY($1)
+			  ORDER BY taxonomy`
+
+	return p.fetch(ctx, query, pq.Array(categoryIDs))
+}
+
+func (p *postgresTaxonomyRepo) FetchByTaxonomy(ctx context.Context, taxonomy string) ([]domain.TaxonomyMapping, error) {
+	query := `SELECT category_id, taxonomy, external_id, updated_at
+			  FROM category_taxonomies
+			  WHERE taxonomy = $1`
+
+	return p.fetch(ctx, query, taxonomy)
+}
+
+func (p *postgresTaxonomyRepo) Put(ctx context.Context, m *domain.TaxonomyMapping) error {
+	query := `INSERT INTO category_taxonomies (category_id, taxonomy, external_id, updated_at)
+			  VALUES ($1, $2, $3, $4)
+			  ON CONFLICT (category_id, taxonomy) DO UPDATE SET external_id = EXCLUDED.external_id, updated_at = EXCLUDED.updated_at`
+
+	_, err := p.DB.ExecContext(ctx, query, m.CategoryID, m.Taxonomy, m.ExternalID, m.UpdatedAt)
+	return translateError(err)
+}
+
+func (p *postgresTaxonomyRepo) Delete(ctx context.Context, categoryID, taxonomy string) error {
+	res, err := p.DB.ExecContext(ctx, `DELETE FROM category_taxonomies WHERE category_id = $1 AND taxonomy = $2`, categoryID, taxonomy)
+	if err != nil {
+		return err
+	}
+
+	return expectOneRow(res)
+}
@@ -0,0 +1,144 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/tokobapak/catalog-service/internal/domain"
+)
+
+// translationTable describes where the translations of one kind of entity live.
+type translationTable struct {
+	table       string
+	ownerTable  string
+	ownerColumn string
+	// hasDescription is false for entities without a description to translate.
+	hasDescription bool
+}
+
+var translationTables = map[domain.TranslatableKind]translationTable{
+	domain.TranslatableCategory: {table: "category_translations", ownerTable: "categories", ownerColumn: "category_id", hasDescription: true},
+	domain.TranslatableBrand:    {table: "brand_translations", ownerTable: "brands", ownerColumn: "brand_id"},
+}
+
+type postgresTranslationRepo struct {
+	DB *sql.DB
+}
+
+func NewPostgresTranslationRepository(db *sql.DB) domain.TranslationRepository {
+	return &postgresTranslationRepo{
+		DB: db,
+	}
+}
+
+func tableFor(kind domain.TranslatableKind) (translationTable, error) {
+	t, ok := translationTables[kind]
+	if !ok {
+		return translationTable{}, fmt.Errorf("%w: unknown translatable kind %q", domain.ErrBadParamInput, kind)
+	}
+	return t, nil
+}
+
+func (p *postgresTranslationRepo) GetByOwners(ctx context.Context, kind domain.TranslatableKind, ids []string) (map[string][]domain.Translation, error) {
+	t, err := tableFor(kind)
+	if err != nil {
+		return nil, err
+	}
+
+	description := "''"
+	if t.hasDescription {
+		description = "COALESCE(description, '')"
+	}
+	query := `SELECT ` + t.ownerColumn + `, locale, name, ` + description + `, updated_at
+			  FROM ` + t.table + ` WHERE ` + t.ownerColumn + ` = ANY($1) ORDER BY locale`
+
+	rows, err := p.DB.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make(map[string][]domain.Translation)
+	for rows.Next() {
+		var (
+			owner string
+			tr    domain.Translation
+		)
+		if err := rows.Scan(&owner, &tr.Locale, &tr.Name, &tr.Description, &tr.UpdatedAt); err != nil {
+			return nil, err
+		}
+		result[owner] = append(result[owner], tr)
+	}
+
+	return result, rows.Err()
+}
+
+func (p *postgresTranslationRepo) Upsert(ctx context.Context, kind domain.TranslatableKind, id string, tr *domain.Translation) error {
+	t, err := tableFor(kind)
+	if err != nil {
+		return err
+	}
+
+	tx, err := p.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if !t.hasDescription {
+		query := `INSERT INTO ` + t.table + ` (` + t.ownerColumn + `, locale, name, updated_at) VALUES ($1, $2, $3, $4)
+				  ON CONFLICT (` + t.ownerColumn + `, locale) DO UPDATE SET name = EXCLUDED.name, updated_at = EXCLUDED.updated_at`
+		_, err = tx.ExecContext(ctx, query, id, tr.Locale, tr.Name, tr.UpdatedAt)
+	} else {
+		query := `INSERT INTO ` + t.table + ` (` + t.ownerColumn + `, locale, name, description, updated_at) VALUES ($1, $2, $3, $4, $5)
+				  ON CONFLICT (` + t.ownerColumn + `, locale) DO UPDATE
+				  SET name = EXCLUDED.name, description = EXCLUDED.description, updated_at = EXCLUDED.updated_at`
+		_, err = tx.ExecContext(ctx, query, id, tr.Locale, tr.Name, tr.Description, tr.UpdatedAt)
+	}
+	if err != nil {
+		return translateError(err)
+	}
+
+	if err = t.touchOwner(ctx, tx, id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (p *postgresTranslationRepo) Delete(ctx context.Context, kind domain.TranslatableKind, id, locale string) error {
+	t, err := tableFor(kind)
+	if err != nil {
+		return err
+	}
+
+	tx, err := p.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `DELETE FROM `+t.table+` WHERE `+t.ownerColumn+` = $1 AND locale = $2`, id, locale)
+	if err != nil {
+		return err
+	}
+
+	if err = expectOneRow(res); err != nil {
+		return err
+	}
+
+	if err = t.touchOwner(ctx, tx, id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// touchOwner bumps the version of the entity whose translations changed, so
+// the ETags of its localized representations change with them.
+func (t translationTable) touchOwner(ctx context.Context, tx *sql.Tx, id string) error {
+	_, err := tx.ExecContext(ctx, `UPDATE `+t.ownerTable+` SET version = version + 1 WHERE id = $1`, id)
+	return err
+}
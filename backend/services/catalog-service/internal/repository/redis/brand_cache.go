@@ -0,0 +1,140 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/tokobapak/catalog-service/internal/domain"
+)
+
+const brandKeyPrefix = "catalog:brand:"
+
+// cachedBrandRepo is a read-through cache in front of another BrandRepository
+// for lookups by ID and slug.
+type cachedBrandRepo struct {
+	repo  domain.BrandRepository
+	cache Cache
+	ttl   time.Duration
+}
+
+func NewCachedBrandRepository(repo domain.BrandRepository, cache Cache, ttl time.Duration) domain.BrandRepository {
+	return &cachedBrandRepo{
+		repo:  repo,
+		cache: cache,
+		ttl:   ttl,
+	}
+}
+
+func brandIDKey(id string) string {
+	return brandKeyPrefix + "id:" + id
+}
+
+func brandSlugKey(slug string) string {
+	return brandKeyPrefix + "slug:" + slug
+}
+
+func (r *cachedBrandRepo) Fetch(ctx context.Context, filter domain.BrandFilter, cursor string, num int64) ([]domain.Brand, domain.PageInfo, error) {
+	return r.repo.Fetch(ctx, filter, cursor, num)
+}
+
+func (r *cachedBrandRepo) GetByID(ctx context.Context, id string) (domain.Brand, error) {
+	return readThrough(ctx, r.cache, brandIDKey(id), r.ttl, func() (domain.Brand, error) {
+		return r.repo.GetByID(ctx, id)
+	})
+}
+
+func (r *cachedBrandRepo) GetBySlug(ctx context.Context, slug string) (domain.Brand, error) {
+	return readThrough(ctx, r.cache, brandSlugKey(slug), r.ttl, func() (domain.Brand, error) {
+		return r.repo.GetBySlug(ctx, slug)
+	})
+}
+
+func (r *cachedBrandRepo) BatchGet(ctx context.Context, ids []string) ([]domain.Brand, error) {
+	return readThroughMany(ctx, r.cache, ids, brandIDKey, func(b domain.Brand) string { return b.ID }, r.ttl, func(misses []string) ([]domain.Brand, error) {
+		return r.repo.BatchGet(ctx, misses)
+	})
+}
+
+func (r *cachedBrandRepo) GetByFormerSlug(ctx context.Context, slug string) (domain.Brand, error) {
+	return r.repo.GetByFormerSlug(ctx, slug)
+}
+
+func (r *cachedBrandRepo) SlugExists(ctx context.Context, slug string) (bool, error) {
+	return r.repo.SlugExists(ctx, slug)
+}
+
+func (r *cachedBrandRepo) Store(ctx context.Context, b *domain.Brand) error {
+	if err := r.repo.Store(ctx, b); err != nil {
+		return err
+	}
+
+	evict(ctx, r.cache, brandIDKey(b.ID), brandSlugKey(b.Slug))
+	return nil
+}
+
+func (r *cachedBrandRepo) Update(ctx context.Context, b *domain.Brand) error {
+	current, err := r.repo.GetByID(ctx, b.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := r.repo.Update(ctx, b); err != nil {
+		return err
+	}
+
+	evict(ctx, r.cache, brandIDKey(b.ID), brandSlugKey(current.Slug), brandSlugKey(b.Slug))
+	return nil
+}
+
+func (r *cachedBrandRepo) Delete(ctx context.Context, id string, version int64) error {
+	current, err := r.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := r.repo.Delete(ctx, id, version); err != nil {
+		return err
+	}
+
+	evict(ctx, r.cache, brandIDKey(id), brandSlugKey(current.Slug))
+	return nil
+}
+
+func (r *cachedBrandRepo) Restore(ctx context.Context, id string) error {
+	if err := r.repo.Restore(ctx, id); err != nil {
+		return err
+	}
+
+	keys := []string{brandIDKey(id)}
+	if restored, err := r.repo.GetByID(ctx, id); err == nil {
+		keys = append(keys, brandSlugKey(restored.Slug))
+	}
+	evict(ctx, r.cache, keys...)
+	return nil
+}
+
+func (r *cachedBrandRepo) Merge(ctx context.Context, id, into string, version int64) error {
+	current, err := r.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := r.repo.Merge(ctx, id, into, version); err != nil {
+		return err
+	}
+
+	evict(ctx, r.cache, brandIDKey(id), brandSlugKey(current.Slug))
+	return nil
+}
+
+func (r *cachedBrandRepo) CategoryIDs(ctx context.Context, id string) ([]string, error) {
+	return r.repo.CategoryIDs(ctx, id)
+}
+
+func (r *cachedBrandRepo) AttachCategory(ctx context.Context, id, categoryID string) error {
+	return r.repo.AttachCategory(ctx, id, categoryID)
+}
+
+func (r *cachedBrandRepo) DetachCategory(ctx context.Context, id, categoryID string) error {
+	return r.repo.DetachCategory(ctx, id, categoryID)
+}
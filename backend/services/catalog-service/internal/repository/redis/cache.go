@@ -0,0 +1,160 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// Cache is the key/value backend behind the caching repositories. A miss is
+// reported through ok rather than as an error, so callers can tell an empty
+// cache apart from an unreachable one.
+type Cache interface {
+	Get(ctx context.Context, key string) (value []byte, ok bool, err error)
+	// GetMany looks up several keys at once; misses are nil in the result.
+	GetMany(ctx context.Context, keys ...string) ([][]byte, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+}
+
+type redisCache struct {
+	client goredis.UniversalClient
+}
+
+func NewRedisCache(client goredis.UniversalClient) Cache {
+	return &redisCache{
+		client: client,
+	}
+}
+
+func (c *redisCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	val, err := c.client.Get(ctx, key).Bytes()
+	if errors.Is(err, goredis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	return val, true, nil
+}
+
+func (c *redisCache) GetMany(ctx context.Context, keys ...string) ([][]byte, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	vals, err := c.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([][]byte, len(vals))
+	for i, v := range vals {
+		if s, ok := v.(string); ok {
+			res[i] = []byte(s)
+		}
+	}
+
+	return res, nil
+}
+
+func (c *redisCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return c.client.Set(ctx, key, value, ttl).Err()
+}
+
+func (c *redisCache) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	return c.client.Del(ctx, keys...).Err()
+}
+
+// readThrough returns the value cached under key, or loads it, caches it and
+// returns it. Cache failures are logged and never fail the read.
+func readThrough[T any](ctx context.Context, c Cache, key string, ttl time.Duration, load func() (T, error)) (T, error) {
+	if raw, ok, err := c.Get(ctx, key); err != nil {
+		log.Printf("cache: get %s: %v", key, err)
+	} else if ok {
+		var cached T
+		if err := json.Unmarshal(raw, &cached); err == nil {
+			return cached, nil
+		}
+	}
+
+	val, err := load()
+	if err != nil {
+		return val, err
+	}
+
+	if raw, err := json.Marshal(val); err == nil {
+		if err := c.Set(ctx, key, raw, ttl); err != nil {
+			log.Printf("cache: set %s: %v", key, err)
+		}
+	}
+
+	return val, nil
+}
+
+// readThroughMany is readThrough for batch lookups: values cached under the
+// keys of ids are served from the cache and the rest are loaded with a single
+// call to load and cached individually. The result is in no particular order.
+func readThroughMany[T any](ctx context.Context, c Cache, ids []string, key func(string) string, idOf func(T) string, ttl time.Duration, load func(ids []string) ([]T, error)) ([]T, error) {
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = key(id)
+	}
+
+	raws, err := c.GetMany(ctx, keys...)
+	if err != nil {
+		log.Printf("cache: get %d keys: %v", len(keys), err)
+		raws = nil
+	}
+
+	var (
+		result []T
+		misses []string
+	)
+	for i, id := range ids {
+		if i < len(raws) && raws[i] != nil {
+			var cached T
+			if err := json.Unmarshal(raws[i], &cached); err == nil {
+				result = append(result, cached)
+				continue
+			}
+		}
+		misses = append(misses, id)
+	}
+
+	if len(misses) == 0 {
+		return result, nil
+	}
+
+	loaded, err := load(misses)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, val := range loaded {
+		if raw, err := json.Marshal(val); err == nil {
+			k := key(idOf(val))
+			if err := c.Set(ctx, k, raw, ttl); err != nil {
+				log.Printf("cache: set %s: %v", k, err)
+			}
+		}
+	}
+
+	return append(result, loaded...), nil
+}
+
+// evict removes keys, logging rather than returning failures: the write that
+// triggered the eviction has already been committed.
+func evict(ctx context.Context, c Cache, keys ...string) {
+	if err := c.Delete(ctx, keys...); err != nil {
+		log.Printf("cache: delete %v: %v", keys, err)
+	}
+}
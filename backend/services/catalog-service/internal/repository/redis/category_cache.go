@@ -0,0 +1,280 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/tokobapak/catalog-service/internal/domain"
+)
+
+const (
+	categoryKeyPrefix  = "catalog:category:"
+	categoryTreeGenKey = categoryKeyPrefix + "tree:gen"
+)
+
+// cachedCategoryRepo is a read-through cache in front of another
+// CategoryRepository. Lookups by ID and slug and the tree are cached; every
+// write evicts the rows it touched and rolls the tree generation so all cached
+// tree variants are dropped at once.
+type cachedCategoryRepo struct {
+	repo  domain.CategoryRepository
+	cache Cache
+	ttl   time.Duration
+}
+
+func NewCachedCategoryRepository(repo domain.CategoryRepository, cache Cache, ttl time.Duration) domain.CategoryRepository {
+	return &cachedCategoryRepo{
+		repo:  repo,
+		cache: cache,
+		ttl:   ttl,
+	}
+}
+
+func categoryIDKey(id string) string {
+	return categoryKeyPrefix + "id:" + id
+}
+
+func categorySlugKey(slug string) string {
+	return categoryKeyPrefix + "slug:" + slug
+}
+
+func (r *cachedCategoryRepo) Fetch(ctx context.Context, filter domain.CategoryFilter, cursor string, num int64) ([]domain.Category, domain.PageInfo, error) {
+	return r.repo.Fetch(ctx, filter, cursor, num)
+}
+
+func (r *cachedCategoryRepo) GetByID(ctx context.Context, id string) (domain.Category, error) {
+	return readThrough(ctx, r.cache, categoryIDKey(id), r.ttl, func() (domain.Category, error) {
+		return r.repo.GetByID(ctx, id)
+	})
+}
+
+func (r *cachedCategoryRepo) GetBySlug(ctx context.Context, slug string) (domain.Category, error) {
+	return readThrough(ctx, r.cache, categorySlugKey(slug), r.ttl, func() (domain.Category, error) {
+		return r.repo.GetBySlug(ctx, slug)
+	})
+}
+
+func (r *cachedCategoryRepo) BatchGet(ctx context.Context, ids []string) ([]domain.Category, error) {
+	return readThroughMany(ctx, r.cache, ids, categoryIDKey, func(c domain.Category) string { return c.ID }, r.ttl, func(misses []string) ([]domain.Category, error) {
+		return r.repo.BatchGet(ctx, misses)
+	})
+}
+
+func (r *cachedCategoryRepo) GetByFormerSlug(ctx context.Context, slug string) (domain.Category, error) {
+	return r.repo.GetByFormerSlug(ctx, slug)
+}
+
+func (r *cachedCategoryRepo) SlugExists(ctx context.Context, slug string) (bool, error) {
+	return r.repo.SlugExists(ctx, slug)
+}
+
+func (r *cachedCategoryRepo) GetByParentID(ctx context.Context, parentID *string) ([]domain.Category, error) {
+	return r.repo.GetByParentID(ctx, parentID)
+}
+
+func (r *cachedCategoryRepo) GetTree(ctx context.Context, maxDepth int, activeOnly bool) ([]domain.Category, error) {
+	key := fmt.Sprintf("%stree:%s:%d:%t", categoryKeyPrefix, r.treeGeneration(ctx), maxDepth, activeOnly)
+	return readThrough(ctx, r.cache, key, r.ttl, func() ([]domain.Category, error) {
+		return r.repo.GetTree(ctx, maxDepth, activeOnly)
+	})
+}
+
+func (r *cachedCategoryRepo) GetAncestors(ctx context.Context, id string) ([]domain.Category, error) {
+	return r.repo.GetAncestors(ctx, id)
+}
+
+func (r *cachedCategoryRepo) GetDescendants(ctx context.Context, id string) ([]domain.Category, error) {
+	return r.repo.GetDescendants(ctx, id)
+}
+
+func (r *cachedCategoryRepo) Move(ctx context.Context, id string, parentID *string, position int) error {
+	current, err := r.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := r.repo.Move(ctx, id, parentID, position); err != nil {
+		return err
+	}
+
+	// Both the old and the new siblings were re-sequenced.
+	r.evictChildren(ctx, current.ParentID)
+	r.evictChildren(ctx, parentID)
+	r.invalidateTree(ctx)
+	return nil
+}
+
+func (r *cachedCategoryRepo) Reorder(ctx context.Context, parentID *string, ids []string) error {
+	if err := r.repo.Reorder(ctx, parentID, ids); err != nil {
+		return err
+	}
+
+	r.evictChildren(ctx, parentID)
+	r.invalidateTree(ctx)
+	return nil
+}
+
+func (r *cachedCategoryRepo) Store(ctx context.Context, c *domain.Category) error {
+	if err := r.repo.Store(ctx, c); err != nil {
+		return err
+	}
+
+	evict(ctx, r.cache, categoryIDKey(c.ID), categorySlugKey(c.Slug))
+	r.invalidateTree(ctx)
+	return nil
+}
+
+func (r *cachedCategoryRepo) Update(ctx context.Context, c *domain.Category) error {
+	current, err := r.repo.GetByID(ctx, c.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := r.repo.Update(ctx, c); err != nil {
+		return err
+	}
+
+	evict(ctx, r.cache, categoryIDKey(c.ID), categorySlugKey(current.Slug), categorySlugKey(c.Slug))
+	// A new parent re-sequences the old and the new siblings, as Move does.
+	if !sameParent(current.ParentID, c.ParentID) {
+		r.evictChildren(ctx, current.ParentID)
+		r.evictChildren(ctx, c.ParentID)
+	}
+	r.invalidateTree(ctx)
+	return nil
+}
+
+func (r *cachedCategoryRepo) Delete(ctx context.Context, id string, version int64, policy domain.DeletePolicy) error {
+	current, err := r.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// A cascade archives the whole subtree; collect it before it disappears from reads.
+	var descendants []domain.Category
+	if policy == domain.DeletePolicyCascade {
+		if descendants, err = r.repo.GetDescendants(ctx, id); err != nil {
+			return err
+		}
+	}
+
+	if err := r.repo.Delete(ctx, id, version, policy); err != nil {
+		return err
+	}
+
+	r.evictCategories(ctx, append(descendants, current))
+	r.evictChildren(ctx, current.ParentID)
+	r.invalidateTree(ctx)
+	return nil
+}
+
+func (r *cachedCategoryRepo) Restore(ctx context.Context, id string) error {
+	if err := r.repo.Restore(ctx, id); err != nil {
+		return err
+	}
+
+	restored, err := r.repo.GetByID(ctx, id)
+	if err == nil {
+		descendants, _ := r.repo.GetDescendants(ctx, id)
+		r.evictCategories(ctx, append(descendants, restored))
+		r.evictChildren(ctx, restored.ParentID)
+	}
+	r.invalidateTree(ctx)
+	return nil
+}
+
+func (r *cachedCategoryRepo) Merge(ctx context.Context, id, into string, version int64) error {
+	current, err := r.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	children, err := r.repo.GetByParentID(ctx, &id)
+	if err != nil {
+		return err
+	}
+
+	if err := r.repo.Merge(ctx, id, into, version); err != nil {
+		return err
+	}
+
+	// The children moved over to the survivor, and the loser left its siblings.
+	r.evictCategories(ctx, append(children, current))
+	r.evictChildren(ctx, &id)
+	r.evictChildren(ctx, &into)
+	r.evictChildren(ctx, current.ParentID)
+	r.invalidateTree(ctx)
+	return nil
+}
+
+func (r *cachedCategoryRepo) Import(ctx context.Context, creates, updates []domain.Category) error {
+	if err := r.repo.Import(ctx, creates, updates); err != nil {
+		return err
+	}
+
+	// An import matches categories by slug and never renames one, so the
+	// slugs it was given are the ones cached.
+	r.evictCategories(ctx, creates)
+	r.evictCategories(ctx, updates)
+	r.invalidateTree(ctx)
+	return nil
+}
+
+func (r *cachedCategoryRepo) Export(ctx context.Context, fn func(domain.Category, string) error) error {
+	return r.repo.Export(ctx, fn)
+}
+
+func (r *cachedCategoryRepo) evictCategories(ctx context.Context, list []domain.Category) {
+	keys := make([]string, 0, 2*len(list))
+	for _, c := range list {
+		keys = append(keys, categoryIDKey(c.ID), categorySlugKey(c.Slug))
+	}
+	evict(ctx, r.cache, keys...)
+}
+
+// evictChildren drops the cached rows of every current child of parentID,
+// whose display order may just have been rewritten.
+func (r *cachedCategoryRepo) evictChildren(ctx context.Context, parentID *string) {
+	children, err := r.repo.GetByParentID(ctx, parentID)
+	if err != nil {
+		return
+	}
+	r.evictCategories(ctx, children)
+}
+
+func (r *cachedCategoryRepo) treeGeneration(ctx context.Context) string {
+	gen, ok, err := r.cache.Get(ctx, categoryTreeGenKey)
+	if err != nil || !ok {
+		return "0"
+	}
+	return string(gen)
+}
+
+func (r *cachedCategoryRepo) invalidateTree(ctx context.Context) {
+	rollCategoryTree(ctx, r.cache)
+}
+
+// rollCategoryTree moves the tree generation on, dropping every cached tree.
+func rollCategoryTree(ctx context.Context, c Cache) {
+	gen := strconv.FormatInt(time.Now().UnixNano(), 36)
+	// The generation outlives the trees it names so a stale tree is never resurrected.
+	if err := c.Set(ctx, categoryTreeGenKey, []byte(gen), 0); err != nil {
+		log.Printf("cache: roll tree generation: %v", err)
+	}
+}
+
+// SyncVisibility changes nothing that is cached: trees are cached with their
+// windows and filtered by the usecase as time passes.
+func (r *cachedCategoryRepo) SyncVisibility(ctx context.Context, now time.Time) (int, error) {
+	return r.repo.SyncVisibility(ctx, now)
+}
+
+func sameParent(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
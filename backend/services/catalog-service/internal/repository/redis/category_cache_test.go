@@ -0,0 +1,186 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/tokobapak/catalog-service/internal/domain"
+)
+
+// stubCategoryRepo serves categories from a map and counts the reads that reach it.
+// Methods the tests do not exercise fall through to the nil embedded interface.
+type stubCategoryRepo struct {
+	domain.CategoryRepository
+	rows      map[string]domain.Category
+	byIDReads int
+	treeReads int
+	batchIDs  []string
+}
+
+func (s *stubCategoryRepo) GetByID(_ context.Context, id string) (domain.Category, error) {
+	s.byIDReads++
+	c, ok := s.rows[id]
+	if !ok {
+		return domain.Category{}, domain.ErrNotFound
+	}
+	return c, nil
+}
+
+func (s *stubCategoryRepo) BatchGet(_ context.Context, ids []string) ([]domain.Category, error) {
+	s.batchIDs = append(s.batchIDs, ids...)
+	var list []domain.Category
+	for _, id := range ids {
+		if c, ok := s.rows[id]; ok {
+			list = append(list, c)
+		}
+	}
+	return list, nil
+}
+
+func (s *stubCategoryRepo) GetTree(_ context.Context, _ int, _ bool) ([]domain.Category, error) {
+	s.treeReads++
+	var list []domain.Category
+	for _, c := range s.rows {
+		list = append(list, c)
+	}
+	return list, nil
+}
+
+func (s *stubCategoryRepo) Store(_ context.Context, c *domain.Category) error {
+	s.rows[c.ID] = *c
+	return nil
+}
+
+func (s *stubCategoryRepo) Update(_ context.Context, c *domain.Category) error {
+	s.rows[c.ID] = *c
+	return nil
+}
+
+func TestCachedCategoryRepoGetByID(t *testing.T) {
+	ctx := context.Background()
+	stub := &stubCategoryRepo{rows: map[string]domain.Category{
+		"1": {ID: "1", Name: "Elektronik", Slug: "elektronik"},
+	}}
+	repo := NewCachedCategoryRepository(stub, NewLRUCache(100), time.Minute)
+
+	for i := 0; i < 3; i++ {
+		c, err := repo.GetByID(ctx, "1")
+		if err != nil || c.Name != "Elektronik" {
+			t.Fatalf("unexpected result: %+v, %v", c, err)
+		}
+	}
+	if stub.byIDReads != 1 {
+		t.Fatalf("expected 1 read through to the repository, got %d", stub.byIDReads)
+	}
+
+	if err := repo.Update(ctx, &domain.Category{ID: "1", Name: "Elektronik & Gadget", Slug: "elektronik"}); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := repo.GetByID(ctx, "1")
+	if err != nil || c.Name != "Elektronik & Gadget" {
+		t.Fatalf("expected the update to evict the cached row, got %+v, %v", c, err)
+	}
+}
+
+func TestCachedCategoryRepoMissesAreNotCached(t *testing.T) {
+	ctx := context.Background()
+	stub := &stubCategoryRepo{rows: map[string]domain.Category{}}
+	repo := NewCachedCategoryRepository(stub, NewLRUCache(100), time.Minute)
+
+	if _, err := repo.GetByID(ctx, "missing"); err != domain.ErrNotFound {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+
+	stub.rows["missing"] = domain.Category{ID: "missing"}
+	if _, err := repo.GetByID(ctx, "missing"); err != nil {
+		t.Fatalf("expected the new row to be found, got %v", err)
+	}
+}
+
+func TestCachedCategoryRepoBatchGetLoadsOnlyMisses(t *testing.T) {
+	ctx := context.Background()
+	stub := &stubCategoryRepo{rows: map[string]domain.Category{
+		"1": {ID: "1", Slug: "elektronik"},
+		"2": {ID: "2", Slug: "fashion"},
+	}}
+	repo := NewCachedCategoryRepository(stub, NewLRUCache(100), time.Minute)
+
+	if _, err := repo.GetByID(ctx, "1"); err != nil {
+		t.Fatal(err)
+	}
+
+	list, err := repo.BatchGet(ctx, []string{"1", "2", "3"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 2 {
+		t.Fatalf("expected 2 categories, got %+v", list)
+	}
+	if len(stub.batchIDs) != 2 || stub.batchIDs[0] != "2" || stub.batchIDs[1] != "3" {
+		t.Fatalf("expected only the misses to reach the repository, got %v", stub.batchIDs)
+	}
+
+	stub.batchIDs = nil
+	if _, err := repo.BatchGet(ctx, []string{"1", "2"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(stub.batchIDs) != 0 {
+		t.Fatalf("expected a fully cached batch, got %v reads", stub.batchIDs)
+	}
+}
+
+func TestCachedCategoryRepoTreeInvalidation(t *testing.T) {
+	ctx := context.Background()
+	stub := &stubCategoryRepo{rows: map[string]domain.Category{
+		"1": {ID: "1", Slug: "elektronik"},
+	}}
+	repo := NewCachedCategoryRepository(stub, NewLRUCache(100), time.Minute)
+
+	repo.GetTree(ctx, 0, true)
+	repo.GetTree(ctx, 0, true)
+	if stub.treeReads != 1 {
+		t.Fatalf("expected the tree to be served from cache, got %d reads", stub.treeReads)
+	}
+
+	if err := repo.Store(ctx, &domain.Category{ID: "2", Slug: "fashion"}); err != nil {
+		t.Fatal(err)
+	}
+
+	tree, _ := repo.GetTree(ctx, 0, true)
+	if stub.treeReads != 2 || len(tree) != 2 {
+		t.Fatalf("expected Store to invalidate the tree, got %d reads and %d nodes", stub.treeReads, len(tree))
+	}
+}
+
+func TestLRUCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	cache := NewLRUCache(2)
+
+	cache.Set(ctx, "a", []byte("1"), 0)
+	cache.Set(ctx, "b", []byte("2"), 0)
+	cache.Get(ctx, "a")
+	cache.Set(ctx, "c", []byte("3"), 0)
+
+	if _, ok, _ := cache.Get(ctx, "b"); ok {
+		t.Fatal("expected b to be evicted")
+	}
+	if _, ok, _ := cache.Get(ctx, "a"); !ok {
+		t.Fatal("expected a to survive as most recently used")
+	}
+}
+
+func TestLRUCacheExpiresEntries(t *testing.T) {
+	ctx := context.Background()
+	cache := NewLRUCache(10).(*lruCache)
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+
+	cache.Set(ctx, "a", []byte("1"), time.Second)
+	now = now.Add(2 * time.Second)
+
+	if _, ok, _ := cache.Get(ctx, "a"); ok {
+		t.Fatal("expected a to have expired")
+	}
+}
@@ -0,0 +1,66 @@
+package redis
+
+import (
+	"context"
+
+	"github.com/tokobapak/catalog-service/internal/domain"
+)
+
+// cachedTranslationRepo evicts the cached category or brand whose translations
+// change, since writing a translation moves the owner's version on.
+// Translations themselves are not cached.
+type cachedTranslationRepo struct {
+	repo       domain.TranslationRepository
+	categories domain.CategoryRepository
+	brands     domain.BrandRepository
+	cache      Cache
+}
+
+func NewCachedTranslationRepository(repo domain.TranslationRepository, categories domain.CategoryRepository, brands domain.BrandRepository, cache Cache) domain.TranslationRepository {
+	return &cachedTranslationRepo{
+		repo:       repo,
+		categories: categories,
+		brands:     brands,
+		cache:      cache,
+	}
+}
+
+func (r *cachedTranslationRepo) GetByOwners(ctx context.Context, kind domain.TranslatableKind, ids []string) (map[string][]domain.Translation, error) {
+	return r.repo.GetByOwners(ctx, kind, ids)
+}
+
+func (r *cachedTranslationRepo) Upsert(ctx context.Context, kind domain.TranslatableKind, id string, t *domain.Translation) error {
+	if err := r.repo.Upsert(ctx, kind, id, t); err != nil {
+		return err
+	}
+
+	r.evictOwner(ctx, kind, id)
+	return nil
+}
+
+func (r *cachedTranslationRepo) Delete(ctx context.Context, kind domain.TranslatableKind, id, locale string) error {
+	if err := r.repo.Delete(ctx, kind, id, locale); err != nil {
+		return err
+	}
+
+	r.evictOwner(ctx, kind, id)
+	return nil
+}
+
+func (r *cachedTranslationRepo) evictOwner(ctx context.Context, kind domain.TranslatableKind, id string) {
+	switch kind {
+	case domain.TranslatableCategory:
+		keys := []string{categoryIDKey(id)}
+		if c, err := r.categories.GetByID(ctx, id); err == nil {
+			keys = append(keys, categorySlugKey(c.Slug))
+		}
+		evict(ctx, r.cache, keys...)
+		rollCategoryTree(ctx, r.cache)
+	case domain.TranslatableBrand:
+		keys := []string{brandIDKey(id)}
+		if b, err := r.brands.GetByID(ctx, id); err == nil {
+			keys = append(keys, brandSlugKey(b.Slug))
+		}
+		evict(ctx, r.cache, keys...)
+	}
+}
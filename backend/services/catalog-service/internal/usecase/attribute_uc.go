@@ -0,0 +1,181 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tokobapak/catalog-service/internal/domain"
+	"github.com/tokobapak/catalog-service/pkg/validator"
+)
+
+var attributeCodePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+type attributeUsecase struct {
+	attributeRepo  domain.AttributeRepository
+	categoryRepo   domain.CategoryRepository
+	contextTimeout time.Duration
+}
+
+func NewAttributeUsecase(a domain.AttributeRepository, c domain.CategoryRepository, timeout time.Duration) domain.AttributeUsecase {
+	return &attributeUsecase{
+		attributeRepo:  a,
+		categoryRepo:   c,
+		contextTimeout: timeout,
+	}
+}
+
+func (uc *attributeUsecase) Fetch(c context.Context) ([]domain.Attribute, error) {
+	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
+	defer cancel()
+	return uc.attributeRepo.Fetch(ctx)
+}
+
+func (uc *attributeUsecase) GetByID(c context.Context, id string) (domain.Attribute, error) {
+	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
+	defer cancel()
+	return uc.attributeRepo.GetByID(ctx, id)
+}
+
+func (uc *attributeUsecase) Store(c context.Context, m *domain.Attribute) error {
+	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
+	defer cancel()
+
+	if err := validateAttribute(m); err != nil {
+		return err
+	}
+
+	existedAttribute, _ := uc.attributeRepo.GetByCode(ctx, m.Code)
+	if existedAttribute.ID != "" {
+		return domain.ErrConflict
+	}
+
+	m.ID = uuid.New().String()
+	m.CreatedAt = time.Now()
+	m.UpdatedAt = time.Now()
+
+	return uc.attributeRepo.Store(ctx, m)
+}
+
+func (uc *attributeUsecase) Update(c context.Context, m *domain.Attribute) error {
+	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
+	defer cancel()
+
+	if err := validateAttribute(m); err != nil {
+		return err
+	}
+
+	existedAttribute, _ := uc.attributeRepo.GetByCode(ctx, m.Code)
+	if existedAttribute.ID != "" && existedAttribute.ID != m.ID {
+		return domain.ErrConflict
+	}
+
+	m.UpdatedAt = time.Now()
+	return uc.attributeRepo.Update(ctx, m)
+}
+
+func (uc *attributeUsecase) Delete(c context.Context, id string) error {
+	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
+	defer cancel()
+	return uc.attributeRepo.Delete(ctx, id)
+}
+
+func (uc *attributeUsecase) Attach(c context.Context, categoryID, attributeID string, isRequired *bool) error {
+	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
+	defer cancel()
+
+	if _, err := uc.categoryRepo.GetByID(ctx, categoryID); err != nil {
+		return err
+	}
+
+	if _, err := uc.attributeRepo.GetByID(ctx, attributeID); err != nil {
+		return err
+	}
+
+	return uc.attributeRepo.Attach(ctx, categoryID, attributeID, isRequired)
+}
+
+func (uc *attributeUsecase) Detach(c context.Context, categoryID, attributeID string) error {
+	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
+	defer cancel()
+	return uc.attributeRepo.Detach(ctx, categoryID, attributeID)
+}
+
+func (uc *attributeUsecase) GetEffective(c context.Context, categoryID string) ([]domain.EffectiveAttribute, error) {
+	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
+	defer cancel()
+
+	ancestors, err := uc.categoryRepo.GetAncestors(ctx, categoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(ancestors))
+	for _, a := range ancestors {
+		ids = append(ids, a.ID)
+	}
+
+	links, err := uc.attributeRepo.GetByCategoryIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	return resolveEffectiveAttributes(ids, links), nil
+}
+
+// resolveEffectiveAttributes walks the category chain from the root down and
+// lets attachments on deeper categories override those made higher up.
+func resolveEffectiveAttributes(chain []string, links []domain.CategoryAttribute) []domain.EffectiveAttribute {
+	byCategory := make(map[string][]domain.CategoryAttribute)
+	for _, l := range links {
+		byCategory[l.CategoryID] = append(byCategory[l.CategoryID], l)
+	}
+
+	var order []string
+	resolved := make(map[string]domain.EffectiveAttribute)
+	for _, categoryID := range chain {
+		for _, l := range byCategory[categoryID] {
+			attr := l.Attribute
+			previous, seen := resolved[attr.ID]
+			switch {
+			case l.IsRequired != nil:
+				attr.IsRequired = *l.IsRequired
+			case seen:
+				// Re-attaching without an override keeps the one inherited from above.
+				attr.IsRequired = previous.IsRequired
+			}
+			if !seen {
+				order = append(order, attr.ID)
+			}
+			resolved[attr.ID] = domain.EffectiveAttribute{Attribute: attr, InheritedFrom: categoryID}
+		}
+	}
+
+	result := make([]domain.EffectiveAttribute, 0, len(order))
+	for _, id := range order {
+		result = append(result, resolved[id])
+	}
+
+	return result
+}
+
+func validateAttribute(m *domain.Attribute) error {
+	m.Code = strings.TrimSpace(m.Code)
+
+	var v validator.Validator
+	checkName(&v, &m.Name)
+	v.Check(validator.Matches(m.Code, attributeCodePattern), "code", validator.CodeInvalid, "must be lowercase letters, digits and underscores")
+	v.Check(m.Type.IsValid(), "type", validator.CodeInvalid, fmt.Sprintf("unknown attribute type %q", m.Type))
+
+	switch m.Type {
+	case domain.AttributeTypeEnum, domain.AttributeTypeUnit:
+		v.Check(len(m.AllowedValues) > 0, "allowedValues", validator.CodeRequired, fmt.Sprintf("%s attributes need at least one allowed value", m.Type))
+	default:
+		v.Check(len(m.AllowedValues) == 0, "allowedValues", validator.CodeInvalid, fmt.Sprintf("%s attributes do not take allowed values", m.Type))
+	}
+
+	return invalid(&v)
+}
@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/tokobapak/catalog-service/internal/domain"
+)
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func TestResolveEffectiveAttributes(t *testing.T) {
+	warna := domain.Attribute{ID: "warna", Type: domain.AttributeTypeEnum}
+	layar := domain.Attribute{ID: "layar", Type: domain.AttributeTypeUnit}
+
+	chain := []string{"elektronik", "handphone", "android"}
+	links := []domain.CategoryAttribute{
+		{CategoryID: "elektronik", Attribute: warna},
+		{CategoryID: "handphone", Attribute: layar},
+		{CategoryID: "android", Attribute: warna, IsRequired: boolPtr(true)},
+		{CategoryID: "fashion", Attribute: layar},
+	}
+
+	got := resolveEffectiveAttributes(chain, links)
+	if len(got) != 2 {
+		t.Fatalf("expected 2 effective attributes, got %d: %+v", len(got), got)
+	}
+
+	if got[0].ID != "warna" || got[0].InheritedFrom != "android" || !got[0].IsRequired {
+		t.Errorf("expected warna overridden as required by android, got %+v", got[0])
+	}
+
+	if got[1].ID != "layar" || got[1].InheritedFrom != "handphone" || got[1].IsRequired {
+		t.Errorf("expected layar inherited from handphone, got %+v", got[1])
+	}
+}
+
+func TestResolveEffectiveAttributesKeepsInheritedOverride(t *testing.T) {
+	garansi := domain.Attribute{ID: "garansi", Type: domain.AttributeTypeBoolean}
+
+	chain := []string{"elektronik", "handphone"}
+	links := []domain.CategoryAttribute{
+		{CategoryID: "elektronik", Attribute: garansi, IsRequired: boolPtr(true)},
+		{CategoryID: "handphone", Attribute: garansi},
+	}
+
+	got := resolveEffectiveAttributes(chain, links)
+	if len(got) != 1 || got[0].InheritedFrom != "handphone" || !got[0].IsRequired {
+		t.Fatalf("expected garansi still required after re-attaching without an override, got %+v", got)
+	}
+}
@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"fmt"
+
+	"github.com/tokobapak/catalog-service/internal/domain"
+)
+
+// uniqueIDs validates the ids of a batch lookup and drops duplicates, keeping
+// the first occurrence of each.
+func uniqueIDs(ids []string) ([]string, error) {
+	seen := make(map[string]bool, len(ids))
+	unique := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if id == "" {
+			return nil, fmt.Errorf("%w: empty id", domain.ErrBadParamInput)
+		}
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		unique = append(unique, id)
+	}
+
+	if len(unique) > domain.MaxBatchSize {
+		return nil, fmt.Errorf("%w: at most %d ids per batch", domain.ErrBadParamInput, domain.MaxBatchSize)
+	}
+
+	return unique, nil
+}
+
+// orderBatch arranges found in the order of ids and reports the ids that
+// matched nothing.
+func orderBatch[T any](ids []string, found []T, idOf func(T) string) ([]T, []string) {
+	byID := make(map[string]T, len(found))
+	for _, item := range found {
+		byID[idOf(item)] = item
+	}
+
+	ordered := make([]T, 0, len(found))
+	missing := make([]string, 0)
+	for _, id := range ids {
+		item, ok := byID[id]
+		if !ok {
+			missing = append(missing, id)
+			continue
+		}
+		ordered = append(ordered, item)
+	}
+
+	return ordered, missing
+}
@@ -0,0 +1,44 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/tokobapak/catalog-service/internal/domain"
+)
+
+func TestUniqueIDs(t *testing.T) {
+	got, err := uniqueIDs([]string{"b", "a", "b", "c", "a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"b", "a", "c"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+
+	if _, err := uniqueIDs([]string{"a", ""}); !errors.Is(err, domain.ErrBadParamInput) {
+		t.Fatalf("expected ErrBadParamInput for an empty id, got %v", err)
+	}
+
+	tooMany := make([]string, domain.MaxBatchSize+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprint(i)
+	}
+	if _, err := uniqueIDs(tooMany); !errors.Is(err, domain.ErrBadParamInput) {
+		t.Fatalf("expected ErrBadParamInput above the batch limit, got %v", err)
+	}
+}
+
+func TestOrderBatch(t *testing.T) {
+	found := []domain.Brand{{ID: "c"}, {ID: "a"}}
+
+	list, missing := orderBatch([]string{"a", "b", "c"}, found, func(b domain.Brand) string { return b.ID })
+	if len(list) != 2 || list[0].ID != "a" || list[1].ID != "c" {
+		t.Fatalf("expected brands in request order, got %+v", list)
+	}
+	if !reflect.DeepEqual(missing, []string{"b"}) {
+		t.Fatalf("expected b to be missing, got %v", missing)
+	}
+}
@@ -2,33 +2,117 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/tokobapak/catalog-service/internal/domain"
+	"github.com/tokobapak/catalog-service/pkg/validator"
 )
 
 type brandUsecase struct {
 	brandRepo      domain.BrandRepository
+	categoryRepo   domain.CategoryRepository
+	revisionRepo   domain.RevisionRepository
 	contextTimeout time.Duration
 }
 
-func NewBrandUsecase(b domain.BrandRepository, timeout time.Duration) domain.BrandUsecase {
+func NewBrandUsecase(b domain.BrandRepository, c domain.CategoryRepository, r domain.RevisionRepository, timeout time.Duration) domain.BrandUsecase {
 	return &brandUsecase{
 		brandRepo:      b,
+		categoryRepo:   c,
+		revisionRepo:   r,
 		contextTimeout: timeout,
 	}
 }
 
-func (uc *brandUsecase) Fetch(c context.Context, cursor string, num int64) ([]domain.Brand, string, error) {
+func (uc *brandUsecase) Fetch(c context.Context, filter domain.BrandFilter, cursor string, num int64) ([]domain.Brand, domain.PageInfo, error) {
 	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
 	defer cancel()
 
-	if num == 0 {
-		num = 10
+	num, err := pageSize(num)
+	if err != nil {
+		return nil, domain.PageInfo{}, err
 	}
 
-	return uc.brandRepo.Fetch(ctx, cursor, num)
+	filter.Query = strings.TrimSpace(filter.Query)
+	if filter.Sort.Field == "" {
+		filter.Sort.Field = domain.SortByCreatedAt
+	}
+
+	return uc.brandRepo.Fetch(ctx, filter, cursor, num)
+}
+
+func (uc *brandUsecase) FetchByCategory(c context.Context, categoryID string, includeDescendants bool, filter domain.BrandFilter, cursor string, num int64) ([]domain.Brand, domain.PageInfo, error) {
+	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
+	defer cancel()
+
+	if _, err := uc.categoryRepo.GetByID(ctx, categoryID); err != nil {
+		return nil, domain.PageInfo{}, err
+	}
+
+	filter.CategoryIDs = []string{categoryID}
+	if includeDescendants {
+		descendants, err := uc.categoryRepo.GetDescendants(ctx, categoryID)
+		if err != nil {
+			return nil, domain.PageInfo{}, err
+		}
+		for _, d := range descendants {
+			filter.CategoryIDs = append(filter.CategoryIDs, d.ID)
+		}
+	}
+
+	return uc.Fetch(ctx, filter, cursor, num)
+}
+
+func (uc *brandUsecase) GetCategories(c context.Context, id string) ([]domain.Category, error) {
+	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
+	defer cancel()
+
+	if _, err := uc.brandRepo.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	ids, err := uc.brandRepo.CategoryIDs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return []domain.Category{}, nil
+	}
+
+	// Associations with deleted categories are kept for a restore but not listed.
+	list, err := uc.categoryRepo.BatchGet(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list, nil
+}
+
+func (uc *brandUsecase) AttachCategory(c context.Context, id, categoryID string) error {
+	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
+	defer cancel()
+
+	if _, err := uc.brandRepo.GetByID(ctx, id); err != nil {
+		return err
+	}
+
+	if _, err := uc.categoryRepo.GetByID(ctx, categoryID); err != nil {
+		return err
+	}
+
+	return uc.brandRepo.AttachCategory(ctx, id, categoryID)
+}
+
+func (uc *brandUsecase) DetachCategory(c context.Context, id, categoryID string) error {
+	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
+	defer cancel()
+	return uc.brandRepo.DetachCategory(ctx, id, categoryID)
 }
 
 func (uc *brandUsecase) GetByID(c context.Context, id string) (domain.Brand, error) {
@@ -43,19 +127,78 @@ func (uc *brandUsecase) GetBySlug(c context.Context, slug string) (domain.Brand,
 	return uc.brandRepo.GetBySlug(ctx, slug)
 }
 
+func (uc *brandUsecase) ResolveSlug(c context.Context, slug string) (domain.Brand, bool, error) {
+	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
+	defer cancel()
+
+	brand, err := uc.brandRepo.GetBySlug(ctx, slug)
+	if err == nil {
+		return brand, false, nil
+	}
+	if !errors.Is(err, domain.ErrNotFound) {
+		return domain.Brand{}, false, err
+	}
+
+	brand, err = uc.brandRepo.GetByFormerSlug(ctx, slug)
+	if err != nil {
+		return domain.Brand{}, false, err
+	}
+
+	return brand, true, nil
+}
+
+func (uc *brandUsecase) BatchGet(c context.Context, ids []string) ([]domain.Brand, []string, error) {
+	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
+	defer cancel()
+
+	ids, err := uniqueIDs(ids)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(ids) == 0 {
+		return []domain.Brand{}, []string{}, nil
+	}
+
+	found, err := uc.brandRepo.BatchGet(ctx, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	list, missing := orderBatch(ids, found, func(b domain.Brand) string { return b.ID })
+	return list, missing, nil
+}
+
 func (uc *brandUsecase) Store(c context.Context, m *domain.Brand) error {
 	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
 	defer cancel()
 
-	existedBrand, _ := uc.brandRepo.GetBySlug(ctx, m.Slug)
-	if existedBrand.ID != "" {
-		return domain.ErrConflict
+	if err := validateBrand(m); err != nil {
+		return err
 	}
 
 	m.ID = uuid.New().String()
 	m.CreatedAt = time.Now()
 	m.UpdatedAt = time.Now()
 
+	// Without a slug of its own, the brand takes one derived from its name.
+	// Soft-deleted brands and former slugs keep their claim so restores and
+	// old links keep working.
+	if m.Slug == "" {
+		return deriveSlug(ctx, m.Name, uc.brandRepo.SlugExists, func(ctx context.Context, s string) error {
+			m.Slug = s
+			return uc.brandRepo.Store(ctx, m)
+		})
+	}
+
+	s, err := normalizeSlug(m.Slug)
+	if err != nil {
+		return err
+	}
+	m.Slug = s
+	if err := uc.checkSlugFree(ctx, "", s); err != nil {
+		return err
+	}
+
 	return uc.brandRepo.Store(ctx, m)
 }
 
@@ -63,12 +206,154 @@ func (uc *brandUsecase) Update(c context.Context, m *domain.Brand) error {
 	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
 	defer cancel()
 
+	if err := validateBrand(m); err != nil {
+		return err
+	}
+
+	existing, err := uc.brandRepo.GetByID(ctx, m.ID)
+	if err != nil {
+		return err
+	}
+
+	if m.Slug == "" {
+		m.Slug = existing.Slug
+	} else if m.Slug, err = normalizeSlug(m.Slug); err != nil {
+		return err
+	}
+	if m.Slug != existing.Slug {
+		if err := uc.checkSlugFree(ctx, m.ID, m.Slug); err != nil {
+			return err
+		}
+	}
+
 	m.UpdatedAt = time.Now()
 	return uc.brandRepo.Update(ctx, m)
 }
 
-func (uc *brandUsecase) Delete(c context.Context, id string) error {
+func (uc *brandUsecase) Patch(c context.Context, id string, version int64, p domain.BrandPatch) (domain.Brand, error) {
+	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
+	defer cancel()
+
+	current, err := uc.brandRepo.GetByID(ctx, id)
+	if err != nil {
+		return domain.Brand{}, err
+	}
+
+	if err := applyBrandPatch(&current, p); err != nil {
+		return domain.Brand{}, err
+	}
+
+	if current.Version, err = patchVersion(current.Version, version); err != nil {
+		return domain.Brand{}, err
+	}
+	if err := uc.Update(ctx, &current); err != nil {
+		return domain.Brand{}, err
+	}
+
+	return current, nil
+}
+
+// checkSlugFree fails with ErrConflict when s belongs to another brand. A
+// brand may take back one of its own former slugs.
+func (uc *brandUsecase) checkSlugFree(ctx context.Context, id, s string) error {
+	taken, err := uc.brandRepo.SlugExists(ctx, s)
+	if err != nil || !taken {
+		return err
+	}
+
+	if id != "" {
+		former, err := uc.brandRepo.GetByFormerSlug(ctx, s)
+		if err == nil && former.ID == id {
+			return nil
+		}
+		if err != nil && !errors.Is(err, domain.ErrNotFound) {
+			return err
+		}
+	}
+
+	return fmt.Errorf("%w: slug %q is already in use", domain.ErrConflict, s)
+}
+
+func (uc *brandUsecase) Delete(c context.Context, id string, version int64) error {
+	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
+	defer cancel()
+	return uc.brandRepo.Delete(ctx, id, version)
+}
+
+func (uc *brandUsecase) Restore(c context.Context, id string) (domain.Brand, error) {
 	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
 	defer cancel()
-	return uc.brandRepo.Delete(ctx, id)
+
+	if err := uc.brandRepo.Restore(ctx, id); err != nil {
+		return domain.Brand{}, err
+	}
+
+	return uc.brandRepo.GetByID(ctx, id)
+}
+
+func (uc *brandUsecase) Merge(c context.Context, id, into string, version int64) (domain.Brand, error) {
+	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
+	defer cancel()
+
+	if id == into {
+		return domain.Brand{}, fmt.Errorf("%w: a brand cannot be merged into itself", domain.ErrBadParamInput)
+	}
+
+	if _, err := uc.brandRepo.GetByID(ctx, id); err != nil {
+		return domain.Brand{}, err
+	}
+
+	_, err := uc.brandRepo.GetByID(ctx, into)
+	if errors.Is(err, domain.ErrNotFound) {
+		var v validator.Validator
+		v.Add("into", validator.CodeNotFound, fmt.Sprintf("brand %q does not exist", into))
+		return domain.Brand{}, invalid(&v)
+	}
+	if err != nil {
+		return domain.Brand{}, err
+	}
+
+	if err := uc.brandRepo.Merge(ctx, id, into, version); err != nil {
+		return domain.Brand{}, err
+	}
+
+	return uc.brandRepo.GetByID(ctx, into)
+}
+
+func (uc *brandUsecase) History(c context.Context, id, cursor string, num int64) ([]domain.Revision, domain.PageInfo, error) {
+	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
+	defer cancel()
+
+	num, err := pageSize(num)
+	if err != nil {
+		return nil, domain.PageInfo{}, err
+	}
+
+	return uc.revisionRepo.Fetch(ctx, domain.AggregateBrand, id, cursor, num)
+}
+
+func (uc *brandUsecase) Revert(c context.Context, id string, revisionID int64) (domain.Brand, error) {
+	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
+	defer cancel()
+
+	var target domain.Brand
+	if err := revisionState(ctx, uc.revisionRepo, domain.AggregateBrand, id, revisionID, &target); err != nil {
+		return domain.Brand{}, err
+	}
+
+	current, err := uc.brandRepo.GetByID(ctx, id)
+	if err != nil {
+		return domain.Brand{}, err
+	}
+
+	current.Name = target.Name
+	current.Slug = target.Slug
+	current.LogoURL = target.LogoURL
+	current.IsActive = target.IsActive
+
+	if err := uc.Update(ctx, &current); err != nil {
+		return domain.Brand{}, err
+	}
+
+	return current, nil
 }
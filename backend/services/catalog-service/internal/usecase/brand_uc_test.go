@@ -0,0 +1,161 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/tokobapak/catalog-service/internal/domain"
+)
+
+// stubBrandRepo records the filter of the last Fetch. Methods the tests do
+// not exercise fall through to the nil embedded interface.
+type stubBrandRepo struct {
+	domain.BrandRepository
+	filter domain.BrandFilter
+}
+
+func (s *stubBrandRepo) Fetch(_ context.Context, filter domain.BrandFilter, _ string, _ int64) ([]domain.Brand, domain.PageInfo, error) {
+	s.filter = filter
+	return nil, domain.PageInfo{}, nil
+}
+
+type stubCategoryTree struct {
+	domain.CategoryRepository
+	children map[string][]string
+}
+
+func (s *stubCategoryTree) GetByID(_ context.Context, id string) (domain.Category, error) {
+	if _, ok := s.children[id]; !ok {
+		return domain.Category{}, domain.ErrNotFound
+	}
+	return domain.Category{ID: id}, nil
+}
+
+func (s *stubCategoryTree) GetDescendants(_ context.Context, id string) ([]domain.Category, error) {
+	var list []domain.Category
+	for _, child := range s.children[id] {
+		list = append(list, domain.Category{ID: child})
+		more, _ := s.GetDescendants(context.Background(), child)
+		list = append(list, more...)
+	}
+	return list, nil
+}
+
+func TestFetchByCategoryIncludesDescendants(t *testing.T) {
+	ctx := context.Background()
+	brands := &stubBrandRepo{}
+	categories := &stubCategoryTree{children: map[string][]string{
+		"elektronik": {"hp"},
+		"hp":         {"aksesoris"},
+		"aksesoris":  nil,
+	}}
+	uc := NewBrandUsecase(brands, categories, nil, time.Second)
+
+	if _, _, err := uc.FetchByCategory(ctx, "elektronik", true, domain.BrandFilter{}, "", 0); err != nil {
+		t.Fatal(err)
+	}
+	if got := brands.filter.CategoryIDs; len(got) != 3 || got[0] != "elektronik" || got[2] != "aksesoris" {
+		t.Fatalf("expected the whole subtree, got %v", got)
+	}
+
+	if _, _, err := uc.FetchByCategory(ctx, "hp", false, domain.BrandFilter{}, "", 0); err != nil {
+		t.Fatal(err)
+	}
+	if got := brands.filter.CategoryIDs; len(got) != 1 || got[0] != "hp" {
+		t.Fatalf("expected only the category itself, got %v", got)
+	}
+
+	if _, _, err := uc.FetchByCategory(ctx, "missing", true, domain.BrandFilter{}, "", 0); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for an unknown category, got %v", err)
+	}
+}
+
+// stubBrandStore keeps brands in memory, merging and restoring them the way
+// the postgres repository does.
+type stubBrandStore struct {
+	domain.BrandRepository
+	brands map[string]*domain.Brand
+}
+
+func (s *stubBrandStore) GetByID(_ context.Context, id string) (domain.Brand, error) {
+	b, ok := s.brands[id]
+	if !ok || b.DeletedAt != nil {
+		return domain.Brand{}, domain.ErrNotFound
+	}
+	return *b, nil
+}
+
+func (s *stubBrandStore) Merge(_ context.Context, id, into string, version int64) error {
+	loser := s.brands[id]
+	if version != 0 && version != loser.Version {
+		return domain.ErrVersionMismatch
+	}
+	now := time.Now()
+	loser.DeletedAt, loser.MergedInto = &now, &into
+	loser.Version++
+	return nil
+}
+
+func (s *stubBrandStore) Restore(_ context.Context, id string) error {
+	b, ok := s.brands[id]
+	if !ok || b.DeletedAt == nil {
+		return domain.ErrNotFound
+	}
+	if b.MergedInto != nil {
+		return domain.ErrConflict
+	}
+	b.DeletedAt = nil
+	return nil
+}
+
+func TestMergeBrand(t *testing.T) {
+	cases := []struct {
+		name     string
+		id, into string
+		version  int64
+		err      error
+	}{
+		{"into itself", "samsung-official", "samsung-official", 1, domain.ErrBadParamInput},
+		{"unknown duplicate", "missing", "samsung", 1, domain.ErrNotFound},
+		{"unknown survivor", "samsung-official", "missing", 1, domain.ErrValidation},
+		{"stale version", "samsung-official", "samsung", 2, domain.ErrVersionMismatch},
+		{"merged", "samsung-official", "samsung", 1, nil},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := &stubBrandStore{brands: map[string]*domain.Brand{
+				"samsung":          {ID: "samsung", Slug: "samsung", Version: 3},
+				"samsung-official": {ID: "samsung-official", Slug: "samsung-official", Version: 1},
+			}}
+			uc := NewBrandUsecase(repo, nil, nil, time.Second)
+
+			survivor, err := uc.Merge(ctx, tc.id, tc.into, tc.version)
+			if tc.err != nil {
+				if !errors.Is(err, tc.err) {
+					t.Fatalf("expected %v, got %v", tc.err, err)
+				}
+				if loser := repo.brands["samsung-official"]; loser.DeletedAt != nil {
+					t.Fatalf("expected the duplicate left alone, got %+v", loser)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if survivor.ID != "samsung" {
+				t.Fatalf("expected the survivor back, got %+v", survivor)
+			}
+			if loser := repo.brands["samsung-official"]; loser.DeletedAt == nil || loser.MergedInto == nil || *loser.MergedInto != "samsung" {
+				t.Fatalf("expected the duplicate deleted and pointing at samsung, got %+v", loser)
+			}
+
+			// A merged brand stays merged.
+			if _, err := uc.Restore(ctx, "samsung-official"); !errors.Is(err, domain.ErrConflict) {
+				t.Fatalf("expected ErrConflict restoring a merged brand, got %v", err)
+			}
+		})
+	}
+}
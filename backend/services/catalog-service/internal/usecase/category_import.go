@@ -0,0 +1,309 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tokobapak/catalog-service/internal/domain"
+	"github.com/tokobapak/catalog-service/pkg/slug"
+	"github.com/tokobapak/catalog-service/pkg/validator"
+)
+
+// bulkTimeout bounds imports and exports, which read the whole tree.
+const bulkTimeout = time.Minute
+
+// importPlan is the category tree as it will stand after the records seen so
+// far have been applied, keyed by slug.
+type importPlan struct {
+	categories map[string]domain.Category
+	parent     map[string]string
+	children   map[string]map[string]bool
+	// line remembers which record touched each slug, to reject duplicates.
+	line    map[string]int
+	creates []domain.Category
+	updates []domain.Category
+}
+
+func newImportPlan(tree []domain.Category) *importPlan {
+	p := &importPlan{
+		categories: make(map[string]domain.Category, len(tree)),
+		parent:     make(map[string]string, len(tree)),
+		children:   make(map[string]map[string]bool),
+		line:       make(map[string]int),
+	}
+
+	slugs := make(map[string]string, len(tree))
+	for _, c := range tree {
+		slugs[c.ID] = c.Slug
+	}
+	for _, c := range tree {
+		parent := ""
+		if c.ParentID != nil {
+			parent = slugs[*c.ParentID]
+		}
+		p.place(c, parent)
+	}
+
+	return p
+}
+
+// place records c under the category with slug parent, "" for the top level.
+func (p *importPlan) place(c domain.Category, parent string) {
+	if old, ok := p.parent[c.Slug]; ok {
+		delete(p.children[old], c.Slug)
+	}
+	if p.children[parent] == nil {
+		p.children[parent] = make(map[string]bool)
+	}
+	p.children[parent][c.Slug] = true
+	p.parent[c.Slug] = parent
+	p.categories[c.Slug] = c
+}
+
+// path returns the slug path of s in the planned tree.
+func (p *importPlan) path(s string) string {
+	var segments []string
+	for ; s != "" && len(segments) <= domain.MaxCategoryDepth; s = p.parent[s] {
+		segments = append(segments, s)
+	}
+	for i, j := 0, len(segments)-1; i < j; i, j = i+1, j-1 {
+		segments[i], segments[j] = segments[j], segments[i]
+	}
+	return strings.Join(segments, "/")
+}
+
+// height counts the levels of the planned subtree rooted at s.
+func (p *importPlan) height(s string) int {
+	h := 0
+	for child := range p.children[s] {
+		if ch := p.height(child); ch > h {
+			h = ch
+		}
+	}
+	return h + 1
+}
+
+func (uc *categoryUsecase) Import(c context.Context, records []domain.CategoryRecord, dryRun bool) (domain.ImportReport, error) {
+	ctx, cancel := context.WithTimeout(c, bulkTimeout)
+	defer cancel()
+
+	if len(records) == 0 {
+		return domain.ImportReport{}, fmt.Errorf("%w: the import has no records", domain.ErrBadParamInput)
+	}
+	if len(records) > domain.MaxImportRows {
+		return domain.ImportReport{}, fmt.Errorf("%w: at most %d records per import", domain.ErrBadParamInput, domain.MaxImportRows)
+	}
+
+	tree, err := uc.categoryRepo.GetTree(ctx, 0, false)
+	if err != nil {
+		return domain.ImportReport{}, err
+	}
+	plan := newImportPlan(tree)
+
+	// Parents are planned before their children wherever they appear in the file.
+	order := make([]int, len(records))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return strings.Count(records[order[a]].Path, "/") < strings.Count(records[order[b]].Path, "/")
+	})
+
+	report := domain.ImportReport{DryRun: dryRun, Rows: make([]domain.ImportRowResult, len(records))}
+	for _, i := range order {
+		row, err := uc.planRecord(ctx, plan, records[i])
+		if err != nil {
+			return domain.ImportReport{}, err
+		}
+		report.Rows[i] = row
+
+		switch row.Action {
+		case domain.ImportCreate:
+			report.Created++
+		case domain.ImportUpdate:
+			report.Updated++
+		case domain.ImportUnchanged:
+			report.Unchanged++
+		default:
+			report.Failed++
+		}
+	}
+
+	if dryRun || report.Failed > 0 {
+		return report, nil
+	}
+
+	// The plan was made against versions read above; an edit in between
+	// invalidates it as a whole rather than the one category.
+	err = uc.categoryRepo.Import(ctx, plan.creates, plan.updates)
+	if errors.Is(err, domain.ErrVersionMismatch) {
+		return domain.ImportReport{}, fmt.Errorf("%w: the catalog changed during the import, try again", domain.ErrConflict)
+	}
+	if err != nil {
+		return domain.ImportReport{}, err
+	}
+
+	report.Applied = true
+	return report, nil
+}
+
+// planRecord validates rec against the planned tree and, when it is valid,
+// adds it to the plan. Only failures to read the catalog are returned as
+// errors; anything wrong with the record itself is reported on the row.
+func (uc *categoryUsecase) planRecord(ctx context.Context, plan *importPlan, rec domain.CategoryRecord) (domain.ImportRowResult, error) {
+	row := domain.ImportRowResult{Line: rec.Line, Path: rec.Path}
+	fail := func(v *validator.Validator) (domain.ImportRowResult, error) {
+		errors.As(v.Err(), &row.Errors)
+		row.Action = domain.ImportError
+		return row, nil
+	}
+
+	var v validator.Validator
+	segments := strings.Split(rec.Path, "/")
+	seen := make(map[string]bool, len(segments))
+	for _, s := range segments {
+		if s == "" || slug.Make(s) != s || seen[s] {
+			v.Add("path", validator.CodeInvalid, "must be distinct lowercase slugs joined by /, such as elektronik/handphone")
+			return fail(&v)
+		}
+		seen[s] = true
+	}
+	if len(segments) > domain.MaxCategoryDepth {
+		v.Add("path", validator.CodeInvalid, fmt.Sprintf("must not be deeper than %d levels", domain.MaxCategoryDepth))
+		return fail(&v)
+	}
+
+	s := segments[len(segments)-1]
+	if line, dup := plan.line[s]; dup {
+		v.Add("path", validator.CodeInvalid, fmt.Sprintf("%q already appears on line %d", s, line))
+		return fail(&v)
+	}
+
+	parent, parentPath := "", strings.Join(segments[:len(segments)-1], "/")
+	if parentPath != "" {
+		parent = segments[len(segments)-2]
+		if _, ok := plan.categories[parent]; !ok {
+			v.Add("path", validator.CodeNotFound, fmt.Sprintf("parent %q does not exist", parentPath))
+			return fail(&v)
+		}
+		if actual := plan.path(parent); actual != parentPath {
+			v.Add("path", validator.CodeInvalid, fmt.Sprintf("parent %q is at %q", parent, actual))
+			return fail(&v)
+		}
+	}
+
+	current, exists := plan.categories[s]
+	if !exists {
+		taken, err := uc.categoryRepo.SlugExists(ctx, s)
+		if err != nil {
+			return row, err
+		}
+		if taken {
+			v.Add("path", validator.CodeInvalid, fmt.Sprintf("%q belongs to a deleted category or was used before", s))
+			return fail(&v)
+		}
+		current = domain.Category{ID: uuid.New().String(), Slug: s, IsActive: true}
+	}
+
+	next := current
+	applyRecord(&next, rec)
+	if parent != "" {
+		id := plan.categories[parent].ID
+		next.ParentID = &id
+	} else {
+		next.ParentID = nil
+	}
+
+	if err := validateCategory(&next); err != nil {
+		errors.As(err, &row.Errors)
+		row.Action = domain.ImportError
+		return row, nil
+	}
+
+	// A category moved along with its subtree must not push it too deep.
+	if len(segments)-1+plan.height(s) > domain.MaxCategoryDepth {
+		v.Add("path", validator.CodeInvalid, fmt.Sprintf("the subtree would sink below %d levels", domain.MaxCategoryDepth))
+		return fail(&v)
+	}
+
+	now := uc.clock.Now()
+	switch {
+	case !exists:
+		next.CreatedAt, next.UpdatedAt = now, now
+		plan.creates = append(plan.creates, next)
+		row.Action = domain.ImportCreate
+	case sameCategoryFields(current, next):
+		row.Action = domain.ImportUnchanged
+	default:
+		next.UpdatedAt = now
+		plan.updates = append(plan.updates, next)
+		row.Action = domain.ImportUpdate
+	}
+
+	plan.line[s] = rec.Line
+	plan.place(next, parent)
+	row.ID = next.ID
+
+	return row, nil
+}
+
+// applyRecord copies the fields set in rec onto m.
+func applyRecord(m *domain.Category, rec domain.CategoryRecord) {
+	m.Name = rec.Name
+	if rec.Description != nil {
+		m.Description = *rec.Description
+	}
+	if rec.ImageURL != nil {
+		m.ImageURL = rec.ImageURL
+	}
+	if rec.IconURL != nil {
+		m.IconURL = rec.IconURL
+	}
+	if rec.DisplayOrder != nil {
+		m.DisplayOrder = *rec.DisplayOrder
+	}
+	if rec.IsActive != nil {
+		m.IsActive = *rec.IsActive
+	}
+}
+
+// sameCategoryFields reports whether an import would leave a unchanged as b.
+func sameCategoryFields(a, b domain.Category) bool {
+	return a.Name == b.Name &&
+		a.Description == b.Description &&
+		equalPtr(a.ParentID, b.ParentID) &&
+		equalPtr(a.ImageURL, b.ImageURL) &&
+		equalPtr(a.IconURL, b.IconURL) &&
+		a.DisplayOrder == b.DisplayOrder &&
+		a.IsActive == b.IsActive
+}
+
+func equalPtr[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func (uc *categoryUsecase) Export(c context.Context, fn func(domain.CategoryRecord) error) error {
+	ctx, cancel := context.WithTimeout(c, bulkTimeout)
+	defer cancel()
+
+	return uc.categoryRepo.Export(ctx, func(m domain.Category, path string) error {
+		description, order, active := m.Description, m.DisplayOrder, m.IsActive
+		return fn(domain.CategoryRecord{
+			Path:         path,
+			Name:         m.Name,
+			Description:  &description,
+			ImageURL:     m.ImageURL,
+			IconURL:      m.IconURL,
+			DisplayOrder: &order,
+			IsActive:     &active,
+		})
+	})
+}
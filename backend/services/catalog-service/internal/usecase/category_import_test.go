@@ -0,0 +1,119 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/tokobapak/catalog-service/internal/domain"
+)
+
+// stubImportRepo serves a fixed tree and records what Import was asked to write.
+type stubImportRepo struct {
+	domain.CategoryRepository
+	tree    []domain.Category
+	retired map[string]bool
+	creates []domain.Category
+	updates []domain.Category
+}
+
+func (s *stubImportRepo) GetTree(context.Context, int, bool) ([]domain.Category, error) {
+	return s.tree, nil
+}
+
+func (s *stubImportRepo) SlugExists(_ context.Context, slug string) (bool, error) {
+	return s.retired[slug], nil
+}
+
+func (s *stubImportRepo) Import(_ context.Context, creates, updates []domain.Category) error {
+	s.creates, s.updates = creates, updates
+	return nil
+}
+
+func importTree() []domain.Category {
+	elektronik := "1"
+	return []domain.Category{
+		{ID: "1", Name: "Elektronik", Slug: "elektronik", IsActive: true, Version: 3},
+		{ID: "2", Name: "Handphone", Slug: "handphone", ParentID: &elektronik, IsActive: true, Version: 1},
+	}
+}
+
+func TestImportPlansEveryRecord(t *testing.T) {
+	repo := &stubImportRepo{tree: importTree(), retired: map[string]bool{"lama": true}}
+	uc := NewCategoryUsecase(repo, nil, domain.SystemClock{}, time.Second)
+
+	order := 2
+	records := []domain.CategoryRecord{
+		// Children may come before their parents in the file.
+		{Line: 2, Path: "elektronik/handphone/android/aksesoris", Name: "Aksesoris"},
+		{Line: 3, Path: "elektronik/handphone/android", Name: "Android"},
+		{Line: 4, Path: "elektronik/handphone", Name: "Handphone & Tablet", DisplayOrder: &order},
+		{Line: 5, Path: "elektronik", Name: "Elektronik"},
+		{Line: 6, Path: "fashion/pria", Name: "Pria"},
+		{Line: 7, Path: "lama", Name: "Lama"},
+		{Line: 8, Path: "Elektronik", Name: "Bad path"},
+		{Line: 9, Path: "handphone/baru", Name: "Wrong parent path"},
+	}
+
+	report, err := uc.Import(context.Background(), records, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []domain.ImportAction{
+		domain.ImportCreate, domain.ImportCreate, domain.ImportUpdate, domain.ImportUnchanged,
+		domain.ImportError, domain.ImportError, domain.ImportError, domain.ImportError,
+	}
+	for i, row := range report.Rows {
+		if row.Line != records[i].Line || row.Action != want[i] {
+			t.Errorf("line %d: got %s, want %s (%v)", records[i].Line, row.Action, want[i], row.Errors)
+		}
+	}
+	if report.Created != 2 || report.Updated != 1 || report.Unchanged != 1 || report.Failed != 4 || report.Applied {
+		t.Fatalf("unexpected totals %+v", report)
+	}
+	if repo.creates != nil || repo.updates != nil {
+		t.Fatal("a dry run must not write")
+	}
+}
+
+func TestImportAppliesParentsFirst(t *testing.T) {
+	repo := &stubImportRepo{tree: importTree()}
+	uc := NewCategoryUsecase(repo, nil, domain.SystemClock{}, time.Second)
+
+	report, err := uc.Import(context.Background(), []domain.CategoryRecord{
+		{Line: 2, Path: "fashion/pria", Name: "Pria"},
+		{Line: 3, Path: "fashion", Name: "Fashion"},
+		{Line: 4, Path: "fashion/handphone", Name: "Handphone"},
+	}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.Applied || len(repo.creates) != 2 || len(repo.updates) != 1 {
+		t.Fatalf("unexpected plan %+v, creates %v, updates %v", report, repo.creates, repo.updates)
+	}
+
+	fashion, pria := repo.creates[0], repo.creates[1]
+	if fashion.Slug != "fashion" || pria.ParentID == nil || *pria.ParentID != fashion.ID {
+		t.Fatalf("expected pria under the new fashion category, got %+v and %+v", fashion, pria)
+	}
+	moved := repo.updates[0]
+	if moved.ID != "2" || moved.Version != 1 || *moved.ParentID != fashion.ID {
+		t.Fatalf("expected handphone moved under fashion at version 1, got %+v", moved)
+	}
+}
+
+func TestImportRejectsCycles(t *testing.T) {
+	repo := &stubImportRepo{tree: importTree()}
+	uc := NewCategoryUsecase(repo, nil, domain.SystemClock{}, time.Second)
+
+	report, err := uc.Import(context.Background(), []domain.CategoryRecord{
+		{Line: 2, Path: "elektronik/handphone/elektronik", Name: "Elektronik"},
+	}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Failed != 1 || report.Applied || repo.updates != nil {
+		t.Fatalf("expected the cycle to be rejected, got %+v", report)
+	}
+}
@@ -2,68 +2,413 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/tokobapak/catalog-service/internal/domain"
+	"github.com/tokobapak/catalog-service/pkg/validator"
 )
 
 type categoryUsecase struct {
 	categoryRepo   domain.CategoryRepository
+	revisionRepo   domain.RevisionRepository
+	clock          domain.Clock
 	contextTimeout time.Duration
 }
 
-func NewCategoryUsecase(c domain.CategoryRepository, timeout time.Duration) domain.CategoryUsecase {
+func NewCategoryUsecase(c domain.CategoryRepository, r domain.RevisionRepository, clock domain.Clock, timeout time.Duration) domain.CategoryUsecase {
 	return &categoryUsecase{
 		categoryRepo:   c,
+		revisionRepo:   r,
+		clock:          clock,
 		contextTimeout: timeout,
 	}
 }
 
-func (uc *categoryUsecase) Fetch(c context.Context, cursor string, num int64) ([]domain.Category, string, error) {
+func (uc *categoryUsecase) Fetch(c context.Context, filter domain.CategoryFilter, cursor string, num int64) ([]domain.Category, domain.PageInfo, error) {
 	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
 	defer cancel()
 
-	if num == 0 {
-		num = 10
+	num, err := pageSize(num)
+	if err != nil {
+		return nil, domain.PageInfo{}, err
 	}
 
-	return uc.categoryRepo.Fetch(ctx, cursor, num)
+	filter.Query = strings.TrimSpace(filter.Query)
+	if !filter.IncludeScheduled {
+		now := uc.clock.Now()
+		filter.VisibleAt = &now
+	}
+	if filter.Sort.Field == "" {
+		filter.Sort.Field = domain.SortByCreatedAt
+	}
+
+	return uc.categoryRepo.Fetch(ctx, filter, cursor, num)
 }
 
-func (uc *categoryUsecase) GetByID(c context.Context, id string) (domain.Category, error) {
+func (uc *categoryUsecase) GetByID(c context.Context, id string, includeScheduled bool) (domain.Category, error) {
 	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
 	defer cancel()
-	return uc.categoryRepo.GetByID(ctx, id)
+
+	category, err := uc.categoryRepo.GetByID(ctx, id)
+	if err != nil {
+		return domain.Category{}, err
+	}
+
+	return uc.hideScheduled(category, includeScheduled)
+}
+
+func (uc *categoryUsecase) GetBySlug(c context.Context, slug string, includeScheduled bool) (domain.Category, error) {
+	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
+	defer cancel()
+
+	category, err := uc.categoryRepo.GetBySlug(ctx, slug)
+	if err != nil {
+		return domain.Category{}, err
+	}
+
+	return uc.hideScheduled(category, includeScheduled)
+}
+
+func (uc *categoryUsecase) ResolveSlug(c context.Context, slug string, includeScheduled bool) (domain.Category, bool, error) {
+	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
+	defer cancel()
+
+	category, err := uc.categoryRepo.GetBySlug(ctx, slug)
+	moved := errors.Is(err, domain.ErrNotFound)
+	if moved {
+		category, err = uc.categoryRepo.GetByFormerSlug(ctx, slug)
+	}
+	if err != nil {
+		return domain.Category{}, false, err
+	}
+
+	category, err = uc.hideScheduled(category, includeScheduled)
+	return category, moved, err
+}
+
+// hideScheduled answers ErrNotFound for a category outside its visibility
+// window, unless includeScheduled is set.
+func (uc *categoryUsecase) hideScheduled(category domain.Category, includeScheduled bool) (domain.Category, error) {
+	if !includeScheduled && !category.VisibleAt(uc.clock.Now()) {
+		return domain.Category{}, domain.ErrNotFound
+	}
+	return category, nil
+}
+
+func (uc *categoryUsecase) BatchGet(c context.Context, ids []string) ([]domain.Category, []string, error) {
+	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
+	defer cancel()
+
+	ids, err := uniqueIDs(ids)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(ids) == 0 {
+		return []domain.Category{}, []string{}, nil
+	}
+
+	found, err := uc.categoryRepo.BatchGet(ctx, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	list, missing := orderBatch(ids, found, func(c domain.Category) string { return c.ID })
+	return list, missing, nil
+}
+
+func (uc *categoryUsecase) GetTree(c context.Context, maxDepth int, activeOnly, includeScheduled bool) ([]domain.Category, error) {
+	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
+	defer cancel()
+
+	if maxDepth < 0 {
+		return nil, domain.ErrBadParamInput
+	}
+
+	list, err := uc.categoryRepo.GetTree(ctx, maxDepth, activeOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	// Windows are applied here rather than in the repository so that a cached
+	// tree opens and closes them on time.
+	if !includeScheduled {
+		list = visibleSubtrees(list, uc.clock.Now())
+	}
+
+	return buildCategoryTree(list), nil
+}
+
+func (uc *categoryUsecase) GetChildren(c context.Context, id string, includeScheduled bool) ([]domain.Category, error) {
+	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
+	defer cancel()
+
+	parent, err := uc.categoryRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = uc.hideScheduled(parent, includeScheduled); err != nil {
+		return nil, err
+	}
+
+	children, err := uc.categoryRepo.GetByParentID(ctx, &id)
+	if err != nil || includeScheduled {
+		return children, err
+	}
+
+	return visibleSubtrees(children, uc.clock.Now()), nil
+}
+
+// GetAncestors hides the whole trail when any category on it is outside its
+// visibility window, as GetTree hides the subtree of such a category.
+func (uc *categoryUsecase) GetAncestors(c context.Context, id string, includeScheduled bool) ([]domain.Category, error) {
+	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
+	defer cancel()
+
+	ancestors, err := uc.categoryRepo.GetAncestors(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, a := range ancestors {
+		if _, err = uc.hideScheduled(a, includeScheduled); err != nil {
+			return nil, err
+		}
+	}
+
+	return ancestors, nil
 }
 
-func (uc *categoryUsecase) GetBySlug(c context.Context, slug string) (domain.Category, error) {
+func (uc *categoryUsecase) GetDescendants(c context.Context, id string, maxDepth int, includeScheduled bool) ([]domain.Category, error) {
 	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
 	defer cancel()
-	return uc.categoryRepo.GetBySlug(ctx, slug)
+
+	if maxDepth < 0 {
+		return nil, domain.ErrBadParamInput
+	}
+
+	parent, err := uc.categoryRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = uc.hideScheduled(parent, includeScheduled); err != nil {
+		return nil, err
+	}
+
+	descendants, err := uc.categoryRepo.GetDescendants(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !includeScheduled {
+		descendants = visibleSubtrees(descendants, uc.clock.Now())
+	}
+	if maxDepth > 0 {
+		descendants = withinDepth(id, descendants, maxDepth)
+	}
+
+	return descendants, nil
+}
+
+func (uc *categoryUsecase) Move(c context.Context, id string, parentID *string, position int) (domain.Category, error) {
+	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
+	defer cancel()
+
+	if _, err := uc.categoryRepo.GetByID(ctx, id); err != nil {
+		return domain.Category{}, err
+	}
+
+	if err := uc.checkPlacement(ctx, id, parentID); err != nil {
+		return domain.Category{}, err
+	}
+
+	if err := uc.categoryRepo.Move(ctx, id, parentID, position); err != nil {
+		return domain.Category{}, err
+	}
+
+	return uc.categoryRepo.GetByID(ctx, id)
 }
 
-func (uc *categoryUsecase) GetTree(c context.Context) ([]domain.Category, error) {
+func (uc *categoryUsecase) Reorder(c context.Context, parentID *string, ids []string) ([]domain.Category, error) {
 	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
 	defer cancel()
-	
-	// Implementation for basic tree retrieval (fetching roots)
-	// For full tree, recursive logic would be needed, here we simplify to getting roots
-	return uc.categoryRepo.GetByParentID(ctx, nil)
+
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			return nil, fmt.Errorf("%w: duplicate id %q", domain.ErrBadParamInput, id)
+		}
+		seen[id] = true
+	}
+
+	if parentID != nil {
+		if _, err := uc.categoryRepo.GetByID(ctx, *parentID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.categoryRepo.Reorder(ctx, parentID, ids); err != nil {
+		return nil, err
+	}
+
+	return uc.categoryRepo.GetByParentID(ctx, parentID)
+}
+
+// checkPlacement verifies that placing category id under parentID keeps the tree
+// acyclic and within MaxCategoryDepth. An empty id stands for a new category.
+func (uc *categoryUsecase) checkPlacement(ctx context.Context, id string, parentID *string) error {
+	parentDepth := 0
+	if parentID != nil {
+		ancestors, err := uc.categoryRepo.GetAncestors(ctx, *parentID)
+		if errors.Is(err, domain.ErrNotFound) {
+			var v validator.Validator
+			v.Add("parentId", validator.CodeNotFound, fmt.Sprintf("category %q does not exist", *parentID))
+			return invalid(&v)
+		}
+		if err != nil {
+			return err
+		}
+
+		for _, a := range ancestors {
+			if a.ID == id {
+				return domain.ErrCategoryCycle
+			}
+		}
+		parentDepth = len(ancestors)
+	}
+
+	height := 1
+	if id != "" {
+		descendants, err := uc.categoryRepo.GetDescendants(ctx, id)
+		if err != nil {
+			return err
+		}
+		height = subtreeHeight(id, descendants)
+	}
+
+	if parentDepth+height > domain.MaxCategoryDepth {
+		return domain.ErrMaxDepthExceeded
+	}
+
+	return nil
+}
+
+// subtreeHeight returns the number of levels in the subtree rooted at id,
+// counting id itself. descendants must be ordered by depth.
+func subtreeHeight(id string, descendants []domain.Category) int {
+	levels := map[string]int{id: 1}
+	height := 1
+	for _, d := range descendants {
+		if d.ParentID == nil {
+			continue
+		}
+		parentLevel, ok := levels[*d.ParentID]
+		if !ok {
+			continue
+		}
+		levels[d.ID] = parentLevel + 1
+		if levels[d.ID] > height {
+			height = levels[d.ID]
+		}
+	}
+
+	return height
+}
+
+// withinDepth returns the descendants of id at most maxDepth levels below it.
+// descendants must be ordered by depth.
+func withinDepth(id string, descendants []domain.Category, maxDepth int) []domain.Category {
+	levels := map[string]int{id: 0}
+	result := make([]domain.Category, 0, len(descendants))
+	for _, d := range descendants {
+		if d.ParentID == nil {
+			continue
+		}
+		parentLevel, ok := levels[*d.ParentID]
+		if !ok || parentLevel >= maxDepth {
+			continue
+		}
+		levels[d.ID] = parentLevel + 1
+		result = append(result, d)
+	}
+
+	return result
+}
+
+func sameParent(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// buildCategoryTree nests a flat, display-ordered list of categories under their
+// parents and returns the roots. Categories whose parent is absent from the list
+// are treated as roots.
+func buildCategoryTree(list []domain.Category) []domain.Category {
+	known := make(map[string]bool, len(list))
+	for _, c := range list {
+		known[c.ID] = true
+	}
+
+	var roots []domain.Category
+	children := make(map[string][]domain.Category)
+	for _, c := range list {
+		if c.ParentID == nil || !known[*c.ParentID] {
+			roots = append(roots, c)
+			continue
+		}
+		children[*c.ParentID] = append(children[*c.ParentID], c)
+	}
+
+	var attach func(nodes []domain.Category) []domain.Category
+	attach = func(nodes []domain.Category) []domain.Category {
+		for i := range nodes {
+			nodes[i].Children = attach(children[nodes[i].ID])
+		}
+		return nodes
+	}
+
+	return attach(roots)
 }
 
 func (uc *categoryUsecase) Store(c context.Context, m *domain.Category) error {
 	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
 	defer cancel()
 
-	existedCategory, _ := uc.categoryRepo.GetBySlug(ctx, m.Slug)
-	if existedCategory.ID != "" {
-		return domain.ErrConflict
+	if err := validateCategory(m); err != nil {
+		return err
+	}
+
+	if err := uc.checkPlacement(ctx, "", m.ParentID); err != nil {
+		return err
 	}
 
 	m.ID = uuid.New().String()
-	m.CreatedAt = time.Now()
-	m.UpdatedAt = time.Now()
+	m.CreatedAt = uc.clock.Now()
+	m.UpdatedAt = m.CreatedAt
+
+	// Without a slug of its own, the category takes one derived from its
+	// name. Soft-deleted categories and former slugs keep their claim so
+	// restores and old links keep working.
+	if m.Slug == "" {
+		return deriveSlug(ctx, m.Name, uc.categoryRepo.SlugExists, func(ctx context.Context, s string) error {
+			m.Slug = s
+			return uc.categoryRepo.Store(ctx, m)
+		})
+	}
+
+	s, err := normalizeSlug(m.Slug)
+	if err != nil {
+		return err
+	}
+	m.Slug = s
+	if err := uc.checkSlugFree(ctx, "", s); err != nil {
+		return err
+	}
 
 	return uc.categoryRepo.Store(ctx, m)
 }
@@ -72,12 +417,215 @@ func (uc *categoryUsecase) Update(c context.Context, m *domain.Category) error {
 	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
 	defer cancel()
 
-	m.UpdatedAt = time.Now()
+	if err := validateCategory(m); err != nil {
+		return err
+	}
+
+	existing, err := uc.categoryRepo.GetByID(ctx, m.ID)
+	if err != nil {
+		return err
+	}
+
+	if m.Slug == "" {
+		m.Slug = existing.Slug
+	} else if m.Slug, err = normalizeSlug(m.Slug); err != nil {
+		return err
+	}
+	if m.Slug != existing.Slug {
+		if err := uc.checkSlugFree(ctx, m.ID, m.Slug); err != nil {
+			return err
+		}
+	}
+
+	if !sameParent(existing.ParentID, m.ParentID) {
+		if err := uc.checkPlacement(ctx, m.ID, m.ParentID); err != nil {
+			return err
+		}
+	}
+
+	m.UpdatedAt = uc.clock.Now()
 	return uc.categoryRepo.Update(ctx, m)
 }
 
-func (uc *categoryUsecase) Delete(c context.Context, id string) error {
+func (uc *categoryUsecase) Patch(c context.Context, id string, version int64, p domain.CategoryPatch) (domain.Category, error) {
 	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
 	defer cancel()
-	return uc.categoryRepo.Delete(ctx, id)
+
+	current, err := uc.categoryRepo.GetByID(ctx, id)
+	if err != nil {
+		return domain.Category{}, err
+	}
+
+	if err := applyCategoryPatch(&current, p); err != nil {
+		return domain.Category{}, err
+	}
+
+	if current.Version, err = patchVersion(current.Version, version); err != nil {
+		return domain.Category{}, err
+	}
+	if err := uc.Update(ctx, &current); err != nil {
+		return domain.Category{}, err
+	}
+
+	return current, nil
+}
+
+// checkSlugFree fails with ErrConflict when s belongs to another category.
+// A category may take back one of its own former slugs.
+func (uc *categoryUsecase) checkSlugFree(ctx context.Context, id, s string) error {
+	taken, err := uc.categoryRepo.SlugExists(ctx, s)
+	if err != nil || !taken {
+		return err
+	}
+
+	if id != "" {
+		former, err := uc.categoryRepo.GetByFormerSlug(ctx, s)
+		if err == nil && former.ID == id {
+			return nil
+		}
+		if err != nil && !errors.Is(err, domain.ErrNotFound) {
+			return err
+		}
+	}
+
+	return fmt.Errorf("%w: slug %q is already in use", domain.ErrConflict, s)
+}
+
+func (uc *categoryUsecase) Delete(c context.Context, id string, version int64, policy domain.DeletePolicy) error {
+	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
+	defer cancel()
+
+	if policy == "" {
+		policy = domain.DeletePolicyBlock
+	}
+	if !policy.IsValid() {
+		return fmt.Errorf("%w: unknown delete policy %q", domain.ErrBadParamInput, policy)
+	}
+
+	return uc.categoryRepo.Delete(ctx, id, version, policy)
+}
+
+func (uc *categoryUsecase) Restore(c context.Context, id string) (domain.Category, error) {
+	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
+	defer cancel()
+
+	if err := uc.categoryRepo.Restore(ctx, id); err != nil {
+		return domain.Category{}, err
+	}
+
+	return uc.categoryRepo.GetByID(ctx, id)
+}
+
+func (uc *categoryUsecase) Merge(c context.Context, id, into string, version int64) (domain.Category, error) {
+	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
+	defer cancel()
+
+	if id == into {
+		return domain.Category{}, fmt.Errorf("%w: a category cannot be merged into itself", domain.ErrBadParamInput)
+	}
+
+	if _, err := uc.categoryRepo.GetByID(ctx, id); err != nil {
+		return domain.Category{}, err
+	}
+
+	if err := uc.checkMerge(ctx, id, into); err != nil {
+		return domain.Category{}, err
+	}
+
+	if err := uc.categoryRepo.Merge(ctx, id, into, version); err != nil {
+		return domain.Category{}, err
+	}
+
+	return uc.categoryRepo.GetByID(ctx, into)
+}
+
+// checkMerge verifies that category into exists, is not inside the subtree of
+// id, and can take over the children of id without exceeding MaxCategoryDepth.
+func (uc *categoryUsecase) checkMerge(ctx context.Context, id, into string) error {
+	ancestors, err := uc.categoryRepo.GetAncestors(ctx, into)
+	if errors.Is(err, domain.ErrNotFound) {
+		var v validator.Validator
+		v.Add("into", validator.CodeNotFound, fmt.Sprintf("category %q does not exist", into))
+		return invalid(&v)
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, a := range ancestors {
+		if a.ID == id {
+			return domain.ErrCategoryCycle
+		}
+	}
+
+	descendants, err := uc.categoryRepo.GetDescendants(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// The children of id take its place one level below into.
+	if len(ancestors)+subtreeHeight(id, descendants)-1 > domain.MaxCategoryDepth {
+		return domain.ErrMaxDepthExceeded
+	}
+
+	return nil
+}
+
+func (uc *categoryUsecase) History(c context.Context, id, cursor string, num int64) ([]domain.Revision, domain.PageInfo, error) {
+	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
+	defer cancel()
+
+	num, err := pageSize(num)
+	if err != nil {
+		return nil, domain.PageInfo{}, err
+	}
+
+	return uc.revisionRepo.Fetch(ctx, domain.AggregateCategory, id, cursor, num)
+}
+
+func (uc *categoryUsecase) Revert(c context.Context, id string, revisionID int64) (domain.Category, error) {
+	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
+	defer cancel()
+
+	var target domain.Category
+	if err := revisionState(ctx, uc.revisionRepo, domain.AggregateCategory, id, revisionID, &target); err != nil {
+		return domain.Category{}, err
+	}
+
+	current, err := uc.categoryRepo.GetByID(ctx, id)
+	if err != nil {
+		return domain.Category{}, err
+	}
+
+	current.Name = target.Name
+	current.Slug = target.Slug
+	current.Description = target.Description
+	current.ParentID = target.ParentID
+	current.ImageURL = target.ImageURL
+	current.IconURL = target.IconURL
+	current.IsActive = target.IsActive
+	current.VisibleFrom = target.VisibleFrom
+	current.VisibleUntil = target.VisibleUntil
+
+	if err := uc.Update(ctx, &current); err != nil {
+		return domain.Category{}, err
+	}
+
+	return current, nil
+}
+
+// visibleSubtrees drops the categories of list, which is ordered parents
+// first, that are outside their visibility window at t, together with their
+// descendants. Categories whose parent is not in list are kept when visible.
+func visibleSubtrees(list []domain.Category, t time.Time) []domain.Category {
+	hidden := make(map[string]bool)
+	result := make([]domain.Category, 0, len(list))
+	for _, c := range list {
+		if !c.VisibleAt(t) || (c.ParentID != nil && hidden[*c.ParentID]) {
+			hidden[c.ID] = true
+			continue
+		}
+		result = append(result, c)
+	}
+	return result
 }
@@ -0,0 +1,561 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tokobapak/catalog-service/internal/domain"
+)
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func TestBuildCategoryTree(t *testing.T) {
+	// Flat list as returned by the recursive query: ordered by depth, then display order.
+	list := []domain.Category{
+		{ID: "elektronik", DisplayOrder: 0},
+		{ID: "fashion", DisplayOrder: 1},
+		{ID: "handphone", ParentID: strPtr("elektronik"), DisplayOrder: 0},
+		{ID: "laptop", ParentID: strPtr("elektronik"), DisplayOrder: 1},
+		{ID: "pria", ParentID: strPtr("fashion"), DisplayOrder: 0},
+		{ID: "android", ParentID: strPtr("handphone"), DisplayOrder: 0},
+	}
+
+	roots := buildCategoryTree(list)
+
+	if len(roots) != 2 || roots[0].ID != "elektronik" || roots[1].ID != "fashion" {
+		t.Fatalf("unexpected roots: %+v", roots)
+	}
+
+	elektronik := roots[0]
+	if len(elektronik.Children) != 2 || elektronik.Children[0].ID != "handphone" || elektronik.Children[1].ID != "laptop" {
+		t.Fatalf("unexpected children of elektronik: %+v", elektronik.Children)
+	}
+
+	handphone := elektronik.Children[0]
+	if len(handphone.Children) != 1 || handphone.Children[0].ID != "android" {
+		t.Fatalf("unexpected children of handphone: %+v", handphone.Children)
+	}
+
+	if len(roots[1].Children) != 1 || roots[1].Children[0].ID != "pria" {
+		t.Fatalf("unexpected children of fashion: %+v", roots[1].Children)
+	}
+}
+
+func TestBuildCategoryTreeOrphansBecomeRoots(t *testing.T) {
+	// A child whose parent is missing from the list is surfaced as a root, not dropped.
+	list := []domain.Category{
+		{ID: "handphone", ParentID: strPtr("hidden")},
+	}
+
+	roots := buildCategoryTree(list)
+	if len(roots) != 1 || roots[0].ID != "handphone" {
+		t.Fatalf("expected orphan to be returned as root, got %+v", roots)
+	}
+}
+
+func TestSubtreeHeight(t *testing.T) {
+	descendants := []domain.Category{
+		{ID: "handphone", ParentID: strPtr("elektronik")},
+		{ID: "laptop", ParentID: strPtr("elektronik")},
+		{ID: "android", ParentID: strPtr("handphone")},
+	}
+
+	if got := subtreeHeight("elektronik", descendants); got != 3 {
+		t.Fatalf("expected height 3, got %d", got)
+	}
+
+	if got := subtreeHeight("leaf", nil); got != 1 {
+		t.Fatalf("expected height 1 for a leaf, got %d", got)
+	}
+}
+
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time {
+	return time.Time(c)
+}
+
+// stubTreeRepo serves reads from a fixed list of categories and records the
+// filter of the last Fetch.
+type stubTreeRepo struct {
+	domain.CategoryRepository
+	list   []domain.Category
+	filter domain.CategoryFilter
+}
+
+func (s *stubTreeRepo) GetTree(context.Context, int, bool) ([]domain.Category, error) {
+	return s.list, nil
+}
+
+func (s *stubTreeRepo) Fetch(_ context.Context, filter domain.CategoryFilter, _ string, _ int64) ([]domain.Category, domain.PageInfo, error) {
+	s.filter = filter
+	return nil, domain.PageInfo{}, nil
+}
+
+func (s *stubTreeRepo) GetByID(_ context.Context, id string) (domain.Category, error) {
+	for _, c := range s.list {
+		if c.ID == id {
+			return c, nil
+		}
+	}
+	return domain.Category{}, domain.ErrNotFound
+}
+
+func (s *stubTreeRepo) GetBySlug(_ context.Context, slug string) (domain.Category, error) {
+	for _, c := range s.list {
+		if c.Slug == slug {
+			return c, nil
+		}
+	}
+	return domain.Category{}, domain.ErrNotFound
+}
+
+func (s *stubTreeRepo) GetByFormerSlug(context.Context, string) (domain.Category, error) {
+	return domain.Category{}, domain.ErrNotFound
+}
+
+func (s *stubTreeRepo) GetByParentID(_ context.Context, parentID *string) ([]domain.Category, error) {
+	var children []domain.Category
+	for _, c := range s.list {
+		if sameParent(c.ParentID, parentID) {
+			children = append(children, c)
+		}
+	}
+	return children, nil
+}
+
+func (s *stubTreeRepo) GetAncestors(ctx context.Context, id string) ([]domain.Category, error) {
+	var chain []domain.Category
+	for {
+		c, err := s.GetByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		chain = append([]domain.Category{c}, chain...)
+		if c.ParentID == nil {
+			return chain, nil
+		}
+		id = *c.ParentID
+	}
+}
+
+func (s *stubTreeRepo) GetDescendants(_ context.Context, id string) ([]domain.Category, error) {
+	var list []domain.Category
+	for queue := []string{id}; len(queue) > 0; queue = queue[1:] {
+		for _, c := range s.list {
+			if c.ParentID != nil && *c.ParentID == queue[0] {
+				list = append(list, c)
+				queue = append(queue, c.ID)
+			}
+		}
+	}
+	return list, nil
+}
+
+// shopTree is elektronik with handphone, which has android, and laptop below it.
+func shopTree() *stubTreeRepo {
+	return &stubTreeRepo{list: []domain.Category{
+		{ID: "elektronik", Slug: "elektronik"},
+		{ID: "handphone", Slug: "handphone", ParentID: strPtr("elektronik")},
+		{ID: "laptop", Slug: "laptop", ParentID: strPtr("elektronik")},
+		{ID: "android", Slug: "android", ParentID: strPtr("handphone")},
+	}}
+}
+
+func TestGetAncestors(t *testing.T) {
+	uc := NewCategoryUsecase(shopTree(), nil, domain.SystemClock{}, time.Second)
+
+	trail, err := uc.GetAncestors(context.Background(), "android", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, c := range trail {
+		ids = append(ids, c.ID)
+	}
+	if got := strings.Join(ids, ","); got != "elektronik,handphone,android" {
+		t.Fatalf("expected the trail from the root down to the category, got %s", got)
+	}
+
+	if _, err := uc.GetAncestors(context.Background(), "missing", false); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestGetDescendants(t *testing.T) {
+	cases := []struct {
+		id       string
+		maxDepth int
+		want     string
+		err      error
+	}{
+		{"elektronik", 0, "handphone,laptop,android", nil},
+		{"elektronik", 1, "handphone,laptop", nil},
+		{"elektronik", 2, "handphone,laptop,android", nil},
+		{"handphone", 1, "android", nil},
+		{"android", 0, "", nil},
+		{"elektronik", -1, "", domain.ErrBadParamInput},
+		{"missing", 0, "", domain.ErrNotFound},
+	}
+	uc := NewCategoryUsecase(shopTree(), nil, domain.SystemClock{}, time.Second)
+	for _, tc := range cases {
+		list, err := uc.GetDescendants(context.Background(), tc.id, tc.maxDepth, false)
+		if !errors.Is(err, tc.err) {
+			t.Errorf("%s to depth %d: expected %v, got %v", tc.id, tc.maxDepth, tc.err, err)
+			continue
+		}
+		var ids []string
+		for _, c := range list {
+			ids = append(ids, c.ID)
+		}
+		if got := strings.Join(ids, ","); got != tc.want {
+			t.Errorf("%s to depth %d: expected %q, got %q", tc.id, tc.maxDepth, tc.want, got)
+		}
+	}
+}
+
+// stubReorderRepo is a stubTreeRepo whose Reorder records the parents it was
+// given and fails with err.
+type stubReorderRepo struct {
+	*stubTreeRepo
+	parents []*string
+	err     error
+}
+
+func (s *stubReorderRepo) Reorder(_ context.Context, parentID *string, _ []string) error {
+	s.parents = append(s.parents, parentID)
+	return s.err
+}
+
+func TestReorder(t *testing.T) {
+	cases := []struct {
+		name     string
+		parentID *string
+		ids      []string
+		repoErr  error
+		want     string
+		err      error
+	}{
+		{"children", strPtr("elektronik"), []string{"laptop", "handphone"}, nil, "handphone,laptop", nil},
+		{"top level", nil, []string{"elektronik"}, nil, "elektronik", nil},
+		{"duplicate", strPtr("elektronik"), []string{"laptop", "laptop"}, nil, "", domain.ErrBadParamInput},
+		{"unknown parent", strPtr("missing"), []string{"laptop"}, nil, "", domain.ErrNotFound},
+		{"stale list", strPtr("elektronik"), []string{"laptop"}, domain.ErrOrderMismatch, "", domain.ErrOrderMismatch},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &stubReorderRepo{stubTreeRepo: shopTree(), err: tc.repoErr}
+			uc := NewCategoryUsecase(repo, nil, domain.SystemClock{}, time.Second)
+
+			list, err := uc.Reorder(context.Background(), tc.parentID, tc.ids)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("expected %v, got %v", tc.err, err)
+			}
+			if errors.Is(err, domain.ErrBadParamInput) || errors.Is(err, domain.ErrNotFound) {
+				if len(repo.parents) != 0 {
+					t.Fatalf("expected no write, got %d", len(repo.parents))
+				}
+				return
+			}
+			if len(repo.parents) != 1 || !sameParent(repo.parents[0], tc.parentID) {
+				t.Fatalf("expected the order written under %v, got %v", tc.parentID, repo.parents)
+			}
+
+			var ids []string
+			for _, c := range list {
+				ids = append(ids, c.ID)
+			}
+			if got := strings.Join(ids, ","); got != tc.want {
+				t.Fatalf("expected the children %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+// scheduledTree has a campaign category that is visible in March 2025 only.
+func scheduledTree() (repo *stubTreeRepo, start, end time.Time) {
+	start = time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+	end = start.AddDate(0, 1, 0)
+	return &stubTreeRepo{list: []domain.Category{
+		{ID: "elektronik", Slug: "elektronik"},
+		{ID: "ramadan-sale", Slug: "ramadan-sale", VisibleFrom: &start, VisibleUntil: &end},
+		{ID: "takjil", Slug: "takjil", ParentID: strPtr("ramadan-sale")},
+		{ID: "promo-hp", Slug: "promo-hp", ParentID: strPtr("elektronik"), VisibleFrom: &start, VisibleUntil: &end},
+	}}, start, end
+}
+
+func TestGetTreeAppliesVisibilityWindows(t *testing.T) {
+	repo, start, end := scheduledTree()
+
+	cases := []struct {
+		now              time.Time
+		includeScheduled bool
+		roots            int
+	}{
+		{start.Add(-time.Second), false, 1},
+		{start, false, 2},
+		// VisibleUntil is exclusive.
+		{end, false, 1},
+		{end, true, 2},
+	}
+	for _, tc := range cases {
+		uc := NewCategoryUsecase(repo, nil, fixedClock(tc.now), time.Second)
+		roots, err := uc.GetTree(context.Background(), 0, false, tc.includeScheduled)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(roots) != tc.roots {
+			t.Errorf("at %s (includeScheduled %t): expected %d roots, got %+v", tc.now, tc.includeScheduled, tc.roots, roots)
+		}
+		// A hidden campaign takes its children with it rather than orphaning them.
+		for _, r := range roots {
+			if r.ID == "takjil" {
+				t.Errorf("at %s: child of a hidden category surfaced as a root", tc.now)
+			}
+		}
+	}
+}
+
+func TestFetchAppliesVisibilityWindows(t *testing.T) {
+	repo, _, end := scheduledTree()
+	uc := NewCategoryUsecase(repo, nil, fixedClock(end), time.Second)
+	inactive := false
+
+	cases := []struct {
+		filter   domain.CategoryFilter
+		windowed bool
+	}{
+		{domain.CategoryFilter{}, true},
+		// Windows apply whatever isActive asks for.
+		{domain.CategoryFilter{IsActive: &inactive}, true},
+		{domain.CategoryFilter{IncludeScheduled: true}, false},
+	}
+	for _, tc := range cases {
+		if _, _, err := uc.Fetch(context.Background(), tc.filter, "", 0); err != nil {
+			t.Fatal(err)
+		}
+		got := repo.filter.VisibleAt
+		if tc.windowed && (got == nil || !got.Equal(end)) {
+			t.Errorf("%+v: expected VisibleAt %s, got %v", tc.filter, end, got)
+		}
+		if !tc.windowed && got != nil {
+			t.Errorf("%+v: expected no VisibleAt, got %s", tc.filter, got)
+		}
+	}
+}
+
+func TestReadsHideScheduledCategories(t *testing.T) {
+	ctx := context.Background()
+	repo, _, end := scheduledTree()
+	uc := NewCategoryUsecase(repo, nil, fixedClock(end), time.Second)
+
+	if _, err := uc.GetByID(ctx, "ramadan-sale", false); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("GetByID: expected ErrNotFound outside the window, got %v", err)
+	}
+	if _, _, err := uc.ResolveSlug(ctx, "ramadan-sale", false); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("ResolveSlug: expected ErrNotFound outside the window, got %v", err)
+	}
+	if _, err := uc.GetChildren(ctx, "ramadan-sale", false); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("GetChildren: expected ErrNotFound under a hidden parent, got %v", err)
+	}
+	if children, err := uc.GetChildren(ctx, "elektronik", false); err != nil || len(children) != 0 {
+		t.Errorf("GetChildren: expected the hidden child left out, got %+v, %v", children, err)
+	}
+	if descendants, err := uc.GetDescendants(ctx, "elektronik", 0, false); err != nil || len(descendants) != 0 {
+		t.Errorf("GetDescendants: expected the hidden descendant left out, got %+v, %v", descendants, err)
+	}
+	if _, err := uc.GetAncestors(ctx, "promo-hp", false); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("GetAncestors: expected ErrNotFound outside the window, got %v", err)
+	}
+	if _, err := uc.GetAncestors(ctx, "takjil", false); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("GetAncestors: expected ErrNotFound under a hidden ancestor, got %v", err)
+	}
+
+	if c, err := uc.GetByID(ctx, "ramadan-sale", true); err != nil || c.ID != "ramadan-sale" {
+		t.Errorf("GetByID: expected the category with includeScheduled, got %+v, %v", c, err)
+	}
+	if c, _, err := uc.ResolveSlug(ctx, "ramadan-sale", true); err != nil || c.ID != "ramadan-sale" {
+		t.Errorf("ResolveSlug: expected the category with includeScheduled, got %+v, %v", c, err)
+	}
+	if children, err := uc.GetChildren(ctx, "elektronik", true); err != nil || len(children) != 1 {
+		t.Errorf("GetChildren: expected the hidden child with includeScheduled, got %+v, %v", children, err)
+	}
+	if trail, err := uc.GetAncestors(ctx, "takjil", true); err != nil || len(trail) != 2 {
+		t.Errorf("GetAncestors: expected the trail through a hidden ancestor with includeScheduled, got %+v, %v", trail, err)
+	}
+}
+
+// stubMergeTree is a category tree given as child-to-parent links, with ""
+// for roots, that records the merges and updates it performs.
+type stubMergeTree struct {
+	domain.CategoryRepository
+	parents map[string]string
+	merged  []string
+	updated []string
+}
+
+func (s *stubMergeTree) GetByID(_ context.Context, id string) (domain.Category, error) {
+	if _, ok := s.parents[id]; !ok {
+		return domain.Category{}, domain.ErrNotFound
+	}
+	return domain.Category{ID: id}, nil
+}
+
+func (s *stubMergeTree) GetAncestors(_ context.Context, id string) ([]domain.Category, error) {
+	if _, ok := s.parents[id]; !ok {
+		return nil, domain.ErrNotFound
+	}
+	var chain []domain.Category
+	for ; id != ""; id = s.parents[id] {
+		chain = append([]domain.Category{{ID: id}}, chain...)
+	}
+	return chain, nil
+}
+
+func (s *stubMergeTree) GetDescendants(_ context.Context, id string) ([]domain.Category, error) {
+	var list []domain.Category
+	for queue := []string{id}; len(queue) > 0; queue = queue[1:] {
+		for child, parent := range s.parents {
+			if parent == queue[0] {
+				list = append(list, domain.Category{ID: child, ParentID: strPtr(parent)})
+				queue = append(queue, child)
+			}
+		}
+	}
+	return list, nil
+}
+
+func (s *stubMergeTree) Update(_ context.Context, c *domain.Category) error {
+	s.updated = append(s.updated, c.ID)
+	return nil
+}
+
+func (s *stubMergeTree) Merge(_ context.Context, id, into string, _ int64) error {
+	s.merged = append(s.merged, id+">"+into)
+	return nil
+}
+
+func TestMergeCategory(t *testing.T) {
+	cases := []struct {
+		name     string
+		id, into string
+		err      error
+	}{
+		{"into itself", "hp", "hp", domain.ErrBadParamInput},
+		{"unknown duplicate", "missing", "hp", domain.ErrNotFound},
+		{"unknown survivor", "hp", "missing", domain.ErrValidation},
+		{"into own descendant", "hp", "android", domain.ErrCategoryCycle},
+		// The children of hp would land on level 6.
+		{"too deep", "hp", "l5", domain.ErrMaxDepthExceeded},
+		{"children fit", "hp", "l4", nil},
+		{"leaf onto deepest level", "android", "l5", nil},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &stubMergeTree{parents: map[string]string{
+				"l1": "", "l2": "l1", "l3": "l2", "l4": "l3", "l5": "l4",
+				"hp": "", "android": "hp",
+			}}
+			uc := NewCategoryUsecase(repo, nil, domain.SystemClock{}, time.Second)
+
+			survivor, err := uc.Merge(context.Background(), tc.id, tc.into, 0)
+			if tc.err != nil {
+				if !errors.Is(err, tc.err) {
+					t.Fatalf("expected %v, got %v", tc.err, err)
+				}
+				if len(repo.merged) != 0 {
+					t.Fatalf("expected nothing merged, got %v", repo.merged)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if survivor.ID != tc.into || len(repo.merged) != 1 || repo.merged[0] != tc.id+">"+tc.into {
+				t.Fatalf("expected %s merged into %s, got %+v after %v", tc.id, tc.into, survivor, repo.merged)
+			}
+		})
+	}
+}
+
+func TestUpdateRejectsParentInOwnSubtree(t *testing.T) {
+	cases := []struct {
+		name     string
+		parentID *string
+		err      error
+	}{
+		{"under its child", strPtr("android"), domain.ErrCategoryCycle},
+		{"under its grandchild", strPtr("android-go"), domain.ErrCategoryCycle},
+		{"under itself", strPtr("hp"), domain.ErrCategoryCycle},
+		{"too deep", strPtr("l4"), domain.ErrMaxDepthExceeded},
+		{"to the top level", nil, nil},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &stubMergeTree{parents: map[string]string{
+				"l1": "", "l2": "l1", "l3": "l2", "l4": "l3",
+				"elektronik": "", "hp": "elektronik", "android": "hp", "android-go": "android",
+			}}
+			uc := NewCategoryUsecase(repo, nil, domain.SystemClock{}, time.Second)
+
+			err := uc.Update(context.Background(), &domain.Category{ID: "hp", Name: "Handphone", ParentID: tc.parentID})
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("expected %v, got %v", tc.err, err)
+			}
+			if wrote := len(repo.updated) > 0; wrote != (tc.err == nil) {
+				t.Fatalf("expected a write only when the placement is valid, got %v", repo.updated)
+			}
+		})
+	}
+}
+
+// stubDeleteRepo records the delete policies it is given and fails with err.
+type stubDeleteRepo struct {
+	domain.CategoryRepository
+	policies []domain.DeletePolicy
+	err      error
+}
+
+func (s *stubDeleteRepo) Delete(_ context.Context, _ string, _ int64, policy domain.DeletePolicy) error {
+	s.policies = append(s.policies, policy)
+	return s.err
+}
+
+func TestDeletePolicies(t *testing.T) {
+	cases := []struct {
+		policy  domain.DeletePolicy
+		repoErr error
+		want    domain.DeletePolicy
+		err     error
+	}{
+		{"", nil, domain.DeletePolicyBlock, nil},
+		{domain.DeletePolicyBlock, domain.ErrCategoryHasChildren, domain.DeletePolicyBlock, domain.ErrCategoryHasChildren},
+		{domain.DeletePolicyCascade, nil, domain.DeletePolicyCascade, nil},
+		{domain.DeletePolicyReparent, nil, domain.DeletePolicyReparent, nil},
+		{"purge", nil, "", domain.ErrBadParamInput},
+	}
+	for _, tc := range cases {
+		repo := &stubDeleteRepo{err: tc.repoErr}
+		uc := NewCategoryUsecase(repo, nil, domain.SystemClock{}, time.Second)
+
+		err := uc.Delete(context.Background(), "handphone", 0, tc.policy)
+		if !errors.Is(err, tc.err) {
+			t.Errorf("%q: expected %v, got %v", tc.policy, tc.err, err)
+			continue
+		}
+		if tc.want == "" {
+			if len(repo.policies) != 0 {
+				t.Errorf("%q: expected no delete, got %v", tc.policy, repo.policies)
+			}
+			continue
+		}
+		if len(repo.policies) != 1 || repo.policies[0] != tc.want {
+			t.Errorf("%q: expected a delete under %s, got %v", tc.policy, tc.want, repo.policies)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tokobapak/catalog-service/internal/domain"
+	"github.com/tokobapak/catalog-service/pkg/validator"
+)
+
+type feeRuleUsecase struct {
+	feeRuleRepo    domain.FeeRuleRepository
+	categoryRepo   domain.CategoryRepository
+	contextTimeout time.Duration
+}
+
+func NewFeeRuleUsecase(f domain.FeeRuleRepository, c domain.CategoryRepository, timeout time.Duration) domain.FeeRuleUsecase {
+	return &feeRuleUsecase{
+		feeRuleRepo:    f,
+		categoryRepo:   c,
+		contextTimeout: timeout,
+	}
+}
+
+func (uc *feeRuleUsecase) FetchByCategory(c context.Context, categoryID string) ([]domain.FeeRule, error) {
+	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
+	defer cancel()
+
+	if _, err := uc.categoryRepo.GetByID(ctx, categoryID); err != nil {
+		return nil, err
+	}
+
+	return uc.feeRuleRepo.FetchByCategory(ctx, categoryID)
+}
+
+func (uc *feeRuleUsecase) Store(c context.Context, m *domain.FeeRule) error {
+	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
+	defer cancel()
+
+	if err := validateFeeRule(m); err != nil {
+		return err
+	}
+
+	if _, err := uc.categoryRepo.GetByID(ctx, m.CategoryID); err != nil {
+		return err
+	}
+
+	m.ID = uuid.New().String()
+	m.CreatedAt = time.Now()
+	m.UpdatedAt = time.Now()
+
+	return uc.feeRuleRepo.Store(ctx, m)
+}
+
+func (uc *feeRuleUsecase) Update(c context.Context, m *domain.FeeRule) error {
+	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
+	defer cancel()
+
+	if err := validateFeeRule(m); err != nil {
+		return err
+	}
+
+	current, err := uc.rule(ctx, m.CategoryID, m.ID)
+	if err != nil {
+		return err
+	}
+
+	m.CreatedAt = current.CreatedAt
+	m.UpdatedAt = time.Now()
+	return uc.feeRuleRepo.Update(ctx, m)
+}
+
+func (uc *feeRuleUsecase) Delete(c context.Context, categoryID, id string) error {
+	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
+	defer cancel()
+
+	if _, err := uc.rule(ctx, categoryID, id); err != nil {
+		return err
+	}
+
+	return uc.feeRuleRepo.Delete(ctx, id)
+}
+
+// rule returns the rule id, or ErrNotFound when it belongs to another
+// category than categoryID.
+func (uc *feeRuleUsecase) rule(ctx context.Context, categoryID, id string) (domain.FeeRule, error) {
+	r, err := uc.feeRuleRepo.GetByID(ctx, id)
+	if err != nil {
+		return domain.FeeRule{}, err
+	}
+	if r.CategoryID != categoryID {
+		return domain.FeeRule{}, domain.ErrNotFound
+	}
+	return r, nil
+}
+
+// GetEffective looks up the current ancestors of the category rather than
+// those it had at t: fee periods are kept, tree history is not.
+func (uc *feeRuleUsecase) GetEffective(c context.Context, categoryID string, t time.Time) (domain.EffectiveFee, error) {
+	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
+	defer cancel()
+
+	ancestors, err := uc.categoryRepo.GetAncestors(ctx, categoryID)
+	if err != nil {
+		return domain.EffectiveFee{}, err
+	}
+
+	ids := make([]string, 0, len(ancestors))
+	for _, a := range ancestors {
+		ids = append(ids, a.ID)
+	}
+
+	rules, err := uc.feeRuleRepo.GetInForce(ctx, ids, t)
+	if err != nil {
+		return domain.EffectiveFee{}, err
+	}
+
+	return resolveEffectiveFee(ids, rules, t)
+}
+
+// resolveEffectiveFee picks the rule in force at t on the deepest category of
+// chain, which runs from the root down.
+func resolveEffectiveFee(chain []string, rules []domain.FeeRule, t time.Time) (domain.EffectiveFee, error) {
+	byCategory := make(map[string]domain.FeeRule)
+	for _, r := range rules {
+		if r.InForce(t) {
+			byCategory[r.CategoryID] = r
+		}
+	}
+
+	for i := len(chain) - 1; i >= 0; i-- {
+		if r, ok := byCategory[chain[i]]; ok {
+			return domain.EffectiveFee{FeeRule: r, At: t, InheritedFrom: chain[i]}, nil
+		}
+	}
+
+	return domain.EffectiveFee{}, fmt.Errorf("%w: no fee rule is in force for the category or its ancestors at %s", domain.ErrNotFound, t.Format(time.RFC3339))
+}
+
+func validateFeeRule(m *domain.FeeRule) error {
+	var v validator.Validator
+	v.Check(m.CommissionBasisPoints >= 0, "commissionBasisPoints", validator.CodeMin, "must not be negative")
+	v.Check(m.CommissionBasisPoints <= domain.MaxCommissionBasisPoints, "commissionBasisPoints", validator.CodeInvalid, fmt.Sprintf("must be at most %d, a commission of 100%%", domain.MaxCommissionBasisPoints))
+	v.Check(m.FixedFee >= 0, "fixedFee", validator.CodeMin, "must not be negative")
+	v.Check(!m.EffectiveFrom.IsZero(), "effectiveFrom", validator.CodeRequired, "must be set")
+	if m.EffectiveTo != nil {
+		v.Check(m.EffectiveTo.After(m.EffectiveFrom), "effectiveTo", validator.CodeInvalid, "must be after effectiveFrom")
+	}
+	return invalid(&v)
+}
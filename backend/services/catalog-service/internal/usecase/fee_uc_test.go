@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/tokobapak/catalog-service/internal/domain"
+)
+
+func TestResolveEffectiveFee(t *testing.T) {
+	jan := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	feb := jan.AddDate(0, 1, 0)
+	chain := []string{"elektronik", "handphone", "android"}
+	rules := []domain.FeeRule{
+		{ID: "base", CategoryID: "elektronik", CommissionBasisPoints: 500, EffectiveFrom: jan},
+		{ID: "promo", CategoryID: "handphone", CommissionBasisPoints: 250, FixedFee: 1000, EffectiveFrom: jan, EffectiveTo: &feb},
+	}
+
+	cases := []struct {
+		at   time.Time
+		want string
+	}{
+		{jan, "promo"},
+		{feb.Add(-time.Second), "promo"},
+		// EffectiveTo is exclusive, so the promotion has ended at feb.
+		{feb, "base"},
+	}
+	for _, tc := range cases {
+		fee, err := resolveEffectiveFee(chain, rules, tc.at)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fee.ID != tc.want || !fee.At.Equal(tc.at) {
+			t.Errorf("at %s: expected rule %s, got %+v", tc.at, tc.want, fee)
+		}
+	}
+
+	fee, _ := resolveEffectiveFee(chain, rules, jan)
+	if fee.InheritedFrom != "handphone" {
+		t.Errorf("expected the rule inherited from handphone, got %s", fee.InheritedFrom)
+	}
+
+	if _, err := resolveEffectiveFee(chain, rules, jan.Add(-time.Second)); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound before any rule, got %v", err)
+	}
+}
+
+func TestValidateFeeRule(t *testing.T) {
+	from := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(-time.Hour)
+
+	cases := []struct {
+		rule domain.FeeRule
+		ok   bool
+	}{
+		{domain.FeeRule{CommissionBasisPoints: 1250, FixedFee: 2000, EffectiveFrom: from}, true},
+		{domain.FeeRule{CommissionBasisPoints: 10001, EffectiveFrom: from}, false},
+		{domain.FeeRule{CommissionBasisPoints: -1, EffectiveFrom: from}, false},
+		{domain.FeeRule{FixedFee: -1, EffectiveFrom: from}, false},
+		{domain.FeeRule{CommissionBasisPoints: 500}, false},
+		{domain.FeeRule{CommissionBasisPoints: 500, EffectiveFrom: from, EffectiveTo: &to}, false},
+	}
+	for _, tc := range cases {
+		err := validateFeeRule(&tc.rule)
+		if tc.ok != (err == nil) || (err != nil && !errors.Is(err, domain.ErrValidation)) {
+			t.Errorf("%+v: unexpected result %v", tc.rule, err)
+		}
+	}
+}
+
+// stubFeeRules serves a fixed set of rules.
+type stubFeeRules struct {
+	domain.FeeRuleRepository
+	rules []domain.FeeRule
+}
+
+func (s stubFeeRules) GetInForce(_ context.Context, categoryIDs []string, t time.Time) ([]domain.FeeRule, error) {
+	var inForce []domain.FeeRule
+	for _, r := range s.rules {
+		if slices.Contains(categoryIDs, r.CategoryID) && r.InForce(t) {
+			inForce = append(inForce, r)
+		}
+	}
+	return inForce, nil
+}
+
+func TestGetEffectiveUsesCurrentAncestors(t *testing.T) {
+	jan := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	// android was under handphone in January and has since moved to gadget;
+	// both parents had a rule in force then.
+	categories := &stubMergeTree{parents: map[string]string{
+		"handphone": "", "gadget": "", "android": "gadget",
+	}}
+	rules := stubFeeRules{rules: []domain.FeeRule{
+		{ID: "handphone-fee", CategoryID: "handphone", CommissionBasisPoints: 500, EffectiveFrom: jan},
+		{ID: "gadget-fee", CategoryID: "gadget", CommissionBasisPoints: 750, EffectiveFrom: jan},
+	}}
+	uc := NewFeeRuleUsecase(rules, categories, time.Second)
+
+	fee, err := uc.GetEffective(context.Background(), "android", jan.AddDate(0, 0, 14))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fee.ID != "gadget-fee" || fee.InheritedFrom != "gadget" {
+		t.Fatalf("expected the rule of the current parent, got %+v", fee)
+	}
+}
@@ -0,0 +1,20 @@
+package usecase
+
+import (
+	"fmt"
+
+	"github.com/tokobapak/catalog-service/internal/domain"
+)
+
+// pageSize applies the default page size and clamps num to the maximum.
+func pageSize(num int64) (int64, error) {
+	switch {
+	case num < 0:
+		return 0, fmt.Errorf("%w: num must not be negative", domain.ErrBadParamInput)
+	case num == 0:
+		return domain.DefaultPageSize, nil
+	case num > domain.MaxPageSize:
+		return domain.MaxPageSize, nil
+	}
+	return num, nil
+}
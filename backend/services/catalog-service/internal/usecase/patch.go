@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"fmt"
+
+	"github.com/tokobapak/catalog-service/internal/domain"
+	"github.com/tokobapak/catalog-service/pkg/validator"
+)
+
+// applyCategoryPatch merges p into c. It only rejects nulls for fields that
+// cannot be cleared; the merged category is validated by Update.
+func applyCategoryPatch(c *domain.Category, p domain.CategoryPatch) error {
+	var v validator.Validator
+	patchRequired(&v, &c.Name, p.Name, "name")
+	patchRequired(&v, &c.Slug, p.Slug, "slug")
+	patchRequired(&v, &c.IsActive, p.IsActive, "isActive")
+	if p.Description.Set {
+		c.Description = p.Description.Value
+	}
+	patchOptional(&c.ParentID, p.ParentID)
+	patchOptional(&c.ImageURL, p.ImageURL)
+	patchOptional(&c.IconURL, p.IconURL)
+	patchOptional(&c.VisibleFrom, p.VisibleFrom)
+	patchOptional(&c.VisibleUntil, p.VisibleUntil)
+	return invalid(&v)
+}
+
+// applyBrandPatch merges p into b. It only rejects nulls for fields that
+// cannot be cleared; the merged brand is validated by Update.
+func applyBrandPatch(b *domain.Brand, p domain.BrandPatch) error {
+	var v validator.Validator
+	patchRequired(&v, &b.Name, p.Name, "name")
+	patchRequired(&v, &b.Slug, p.Slug, "slug")
+	patchRequired(&v, &b.IsActive, p.IsActive, "isActive")
+	patchOptional(&b.LogoURL, p.LogoURL)
+	return invalid(&v)
+}
+
+// patchVersion returns the version to write a patch applied to a row read at
+// version read, failing with ErrVersionMismatch when the client expected
+// another one. A client that expects any version (0) gets the one read, so
+// that the write still fails if the row changed since the patch was applied.
+func patchVersion(read, expected int64) (int64, error) {
+	if expected != 0 && expected != read {
+		return 0, fmt.Errorf("%w: expected version %d, current version is %d", domain.ErrVersionMismatch, expected, read)
+	}
+	return read, nil
+}
+
+// patchRequired sets *dst from f unless f is absent or null, recording null
+// as a failure since the field cannot be cleared.
+func patchRequired[T any](v *validator.Validator, dst *T, f domain.PatchField[T], field string) {
+	if !f.Set {
+		return
+	}
+	if f.Null {
+		v.Add(field, validator.CodeRequired, "cannot be null")
+		return
+	}
+	*dst = f.Value
+}
+
+// patchOptional sets or, for null, clears an optional field.
+func patchOptional[T any](dst **T, f domain.PatchField[T]) {
+	switch {
+	case !f.Set:
+	case f.Null:
+		*dst = nil
+	default:
+		val := f.Value
+		*dst = &val
+	}
+}
@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/tokobapak/catalog-service/internal/domain"
+)
+
+func TestApplyCategoryPatch(t *testing.T) {
+	parent, image := "p1", "https://cdn.example.com/a.png"
+	base := domain.Category{Name: "Elektronik", Slug: "elektronik", Description: "Gadget", ParentID: &parent, ImageURL: &image, IsActive: true}
+
+	cases := []struct {
+		patch string
+		check func(domain.Category) bool
+		err   error
+	}{
+		{`{}`, func(c domain.Category) bool { return c.IsActive && c.ParentID != nil && c.Description == "Gadget" }, nil},
+		{`{"name": "Elektronik Rumah"}`, func(c domain.Category) bool { return c.Name == "Elektronik Rumah" && c.IsActive }, nil},
+		{`{"parentId": null, "imageUrl": null}`, func(c domain.Category) bool { return c.ParentID == nil && c.ImageURL == nil && c.Slug == "elektronik" }, nil},
+		{`{"isActive": false, "description": null}`, func(c domain.Category) bool { return !c.IsActive && c.Description == "" }, nil},
+		{`{"name": null}`, nil, domain.ErrValidation},
+		{`{"slug": null}`, nil, domain.ErrValidation},
+		{`{"isActive": null}`, nil, domain.ErrValidation},
+	}
+
+	for _, tc := range cases {
+		var p domain.CategoryPatch
+		if err := json.Unmarshal([]byte(tc.patch), &p); err != nil {
+			t.Fatal(err)
+		}
+
+		c := base
+		err := applyCategoryPatch(&c, p)
+		if tc.err != nil {
+			if !errors.Is(err, tc.err) {
+				t.Errorf("%s: expected %v, got %v", tc.patch, tc.err, err)
+			}
+			continue
+		}
+		if err != nil || !tc.check(c) {
+			t.Errorf("%s: unexpected result %+v, %v", tc.patch, c, err)
+		}
+	}
+}
+
+func TestApplyBrandPatchClearsLogo(t *testing.T) {
+	logo := "https://cdn.example.com/logo.png"
+	b := domain.Brand{Name: "Polytron", Slug: "polytron", LogoURL: &logo, IsActive: true}
+
+	var p domain.BrandPatch
+	if err := json.Unmarshal([]byte(`{"logoUrl": null}`), &p); err != nil {
+		t.Fatal(err)
+	}
+	if err := applyBrandPatch(&b, p); err != nil {
+		t.Fatal(err)
+	}
+	if b.LogoURL != nil || b.Name != "Polytron" || !b.IsActive {
+		t.Fatalf("expected only the logo to be cleared, got %+v", b)
+	}
+}
+
+// stubPatchCategory holds a single category and records the version each
+// update is written at.
+type stubPatchCategory struct {
+	domain.CategoryRepository
+	category domain.Category
+	written  []int64
+}
+
+func (s *stubPatchCategory) GetByID(context.Context, string) (domain.Category, error) {
+	return s.category, nil
+}
+
+func (s *stubPatchCategory) Update(_ context.Context, c *domain.Category) error {
+	s.written = append(s.written, c.Version)
+	return nil
+}
+
+func TestPatchChecksVersion(t *testing.T) {
+	cases := []struct {
+		name    string
+		version int64
+		written int64
+		err     error
+	}{
+		{"current version", 3, 3, nil},
+		{"any version", 0, 3, nil},
+		{"stale version", 2, 0, domain.ErrVersionMismatch},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &stubPatchCategory{category: domain.Category{ID: "hp", Name: "Handphone", Slug: "handphone", IsActive: true, Version: 3}}
+			uc := NewCategoryUsecase(repo, nil, domain.SystemClock{}, time.Second)
+
+			var p domain.CategoryPatch
+			if err := json.Unmarshal([]byte(`{"name": "Handphone & Tablet"}`), &p); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err := uc.Patch(context.Background(), "hp", tc.version, p)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("expected %v, got %v", tc.err, err)
+			}
+			if tc.err != nil {
+				if len(repo.written) != 0 {
+					t.Fatalf("expected no write, got %v", repo.written)
+				}
+				return
+			}
+			if len(repo.written) != 1 || repo.written[0] != tc.written {
+				t.Fatalf("expected a write at version %d, got %v", tc.written, repo.written)
+			}
+		})
+	}
+}
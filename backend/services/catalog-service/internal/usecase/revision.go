@@ -0,0 +1,28 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/tokobapak/catalog-service/internal/domain"
+)
+
+// revisionState decodes into v the state revision revisionID left entity id
+// in. A revision of another entity is reported as not found, and one that
+// deleted the entity cannot be reverted to.
+func revisionState(ctx context.Context, repo domain.RevisionRepository, entityType, id string, revisionID int64, v interface{}) error {
+	rev, err := repo.GetByID(ctx, revisionID)
+	if err != nil {
+		return err
+	}
+
+	if rev.EntityType != entityType || rev.EntityID != id {
+		return fmt.Errorf("%w: %s %q has no revision %d", domain.ErrNotFound, entityType, id, revisionID)
+	}
+	if rev.Action == domain.EventCategoryDeleted.Action() {
+		return fmt.Errorf("%w: revision %d deleted the %s, restore it instead", domain.ErrBadParamInput, revisionID, entityType)
+	}
+
+	return json.Unmarshal(rev.After, v)
+}
@@ -0,0 +1,47 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/tokobapak/catalog-service/internal/domain"
+)
+
+type stubRevisionRepo struct {
+	domain.RevisionRepository
+	rows map[int64]domain.Revision
+}
+
+func (s *stubRevisionRepo) GetByID(_ context.Context, id int64) (domain.Revision, error) {
+	rev, ok := s.rows[id]
+	if !ok {
+		return domain.Revision{}, domain.ErrNotFound
+	}
+	return rev, nil
+}
+
+func TestRevisionState(t *testing.T) {
+	ctx := context.Background()
+	repo := &stubRevisionRepo{rows: map[int64]domain.Revision{
+		1: {ID: 1, EntityType: domain.AggregateCategory, EntityID: "c1", Action: "updated", After: json.RawMessage(`{"id":"c1","name":"Elektronik"}`)},
+		2: {ID: 2, EntityType: domain.AggregateCategory, EntityID: "c1", Action: "deleted", After: json.RawMessage(`{"id":"c1"}`)},
+		3: {ID: 3, EntityType: domain.AggregateBrand, EntityID: "c1", Action: "updated", After: json.RawMessage(`{"id":"c1"}`)},
+	}}
+
+	var c domain.Category
+	if err := revisionState(ctx, repo, domain.AggregateCategory, "c1", 1, &c); err != nil || c.Name != "Elektronik" {
+		t.Fatalf("expected the revision state to decode, got %+v, %v", c, err)
+	}
+
+	if err := revisionState(ctx, repo, domain.AggregateCategory, "c1", 2, &c); !errors.Is(err, domain.ErrBadParamInput) {
+		t.Errorf("expected a delete to be refused, got %v", err)
+	}
+	if err := revisionState(ctx, repo, domain.AggregateCategory, "c2", 1, &c); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("expected another category's revision to be not found, got %v", err)
+	}
+	if err := revisionState(ctx, repo, domain.AggregateCategory, "c1", 3, &c); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("expected a brand revision to be not found, got %v", err)
+	}
+}
@@ -0,0 +1,49 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/tokobapak/catalog-service/internal/domain"
+	"github.com/tokobapak/catalog-service/pkg/slug"
+)
+
+// maxSlugAttempts bounds the -2, -3, ... suffixes tried for a derived slug.
+const maxSlugAttempts = 50
+
+// deriveSlug builds a slug from name, suffixing it until exists reports a
+// free candidate, and hands that candidate to claim to store the new row.
+// Another writer can take the candidate between the check and the insert, so
+// a claim failing with ErrConflict moves on to the next suffix.
+func deriveSlug(ctx context.Context, name string, exists func(context.Context, string) (bool, error), claim func(context.Context, string) error) error {
+	base := slug.Make(name)
+	if base == "" {
+		return fmt.Errorf("%w: cannot derive a slug from name %q", domain.ErrBadParamInput, name)
+	}
+
+	for n := 1; n <= maxSlugAttempts; n++ {
+		candidate := slug.WithSuffix(base, n)
+		taken, err := exists(ctx, candidate)
+		if err != nil {
+			return err
+		}
+		if taken {
+			continue
+		}
+		if err = claim(ctx, candidate); !errors.Is(err, domain.ErrConflict) {
+			return err
+		}
+	}
+
+	return fmt.Errorf("%w: no free slug left for %q", domain.ErrConflict, base)
+}
+
+// normalizeSlug brings a slug chosen by the client into canonical form.
+func normalizeSlug(s string) (string, error) {
+	normalized := slug.Make(s)
+	if normalized == "" {
+		return "", fmt.Errorf("%w: invalid slug %q", domain.ErrBadParamInput, s)
+	}
+	return normalized, nil
+}
@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/tokobapak/catalog-service/internal/domain"
+)
+
+func TestDeriveSlugSuffixesTakenSlugs(t *testing.T) {
+	taken := map[string]bool{"ibu-dan-anak": true, "ibu-dan-anak-2": true}
+	exists := func(_ context.Context, s string) (bool, error) { return taken[s], nil }
+
+	var got string
+	claim := func(_ context.Context, s string) error {
+		got = s
+		return nil
+	}
+	if err := deriveSlug(context.Background(), "Ibu & Anak", exists, claim); err != nil {
+		t.Fatal(err)
+	}
+	if got != "ibu-dan-anak-3" {
+		t.Fatalf("expected ibu-dan-anak-3, got %q", got)
+	}
+
+	if err := deriveSlug(context.Background(), "???", exists, claim); !errors.Is(err, domain.ErrBadParamInput) {
+		t.Fatalf("expected ErrBadParamInput for a name without letters, got %v", err)
+	}
+}
+
+func TestDeriveSlugRetriesLostClaims(t *testing.T) {
+	exists := func(context.Context, string) (bool, error) { return false, nil }
+
+	// Another writer takes the first two candidates after they were checked.
+	var claimed []string
+	claim := func(_ context.Context, s string) error {
+		claimed = append(claimed, s)
+		if len(claimed) < 3 {
+			return fmt.Errorf("%w: duplicate key", domain.ErrConflict)
+		}
+		return nil
+	}
+	if err := deriveSlug(context.Background(), "Elektronik", exists, claim); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(claimed, ","); got != "elektronik,elektronik-2,elektronik-3" {
+		t.Fatalf("expected the next suffix after each lost claim, got %s", got)
+	}
+
+	// Other failures are not retried.
+	claimed = nil
+	claim = func(_ context.Context, s string) error {
+		claimed = append(claimed, s)
+		return domain.ErrNotFound
+	}
+	if err := deriveSlug(context.Background(), "Elektronik", exists, claim); !errors.Is(err, domain.ErrNotFound) || len(claimed) != 1 {
+		t.Fatalf("expected a single failed claim, got %v after %v", err, claimed)
+	}
+}
@@ -0,0 +1,209 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tokobapak/catalog-service/internal/domain"
+	"github.com/tokobapak/catalog-service/pkg/taxonomy"
+	"github.com/tokobapak/catalog-service/pkg/validator"
+)
+
+type taxonomyUsecase struct {
+	taxonomyRepo   domain.TaxonomyRepository
+	categoryRepo   domain.CategoryRepository
+	taxonomies     map[string]*taxonomy.Taxonomy
+	contextTimeout time.Duration
+}
+
+// NewTaxonomyUsecase serves mappings into the given taxonomies, keyed by name.
+func NewTaxonomyUsecase(t domain.TaxonomyRepository, c domain.CategoryRepository, taxonomies map[string]*taxonomy.Taxonomy, timeout time.Duration) domain.TaxonomyUsecase {
+	return &taxonomyUsecase{
+		taxonomyRepo:   t,
+		categoryRepo:   c,
+		taxonomies:     taxonomies,
+		contextTimeout: timeout,
+	}
+}
+
+func (uc *taxonomyUsecase) Taxonomies(_ context.Context) []domain.TaxonomyInfo {
+	list := make([]domain.TaxonomyInfo, 0, len(uc.taxonomies))
+	for _, t := range uc.taxonomies {
+		list = append(list, domain.TaxonomyInfo{Name: t.Name, Version: t.Version, Size: t.Len()})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+// taxonomy returns the loaded taxonomy called name.
+func (uc *taxonomyUsecase) taxonomy(name string) (*taxonomy.Taxonomy, error) {
+	t, ok := uc.taxonomies[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: no taxonomy %q is loaded", domain.ErrNotFound, name)
+	}
+	return t, nil
+}
+
+func (uc *taxonomyUsecase) Put(c context.Context, m *domain.TaxonomyMapping) error {
+	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
+	defer cancel()
+
+	t, err := uc.taxonomy(m.Taxonomy)
+	if err != nil {
+		return err
+	}
+
+	m.ExternalID = strings.TrimSpace(m.ExternalID)
+	var v validator.Validator
+	if !validator.NotBlank(m.ExternalID) {
+		v.Add("externalId", validator.CodeRequired, "must not be empty")
+	} else if _, ok := t.Lookup(m.ExternalID); !ok {
+		v.Add("externalId", validator.CodeNotFound, fmt.Sprintf("is not in the %s taxonomy", m.Taxonomy))
+	}
+	if err := invalid(&v); err != nil {
+		return err
+	}
+
+	if _, err := uc.categoryRepo.GetByID(ctx, m.CategoryID); err != nil {
+		return err
+	}
+
+	m.UpdatedAt = time.Now()
+	return uc.taxonomyRepo.Put(ctx, m)
+}
+
+func (uc *taxonomyUsecase) Delete(c context.Context, categoryID, taxonomy string) error {
+	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
+	defer cancel()
+	return uc.taxonomyRepo.Delete(ctx, categoryID, taxonomy)
+}
+
+func (uc *taxonomyUsecase) GetEffective(c context.Context, categoryID string) ([]domain.EffectiveTaxonomyMapping, error) {
+	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
+	defer cancel()
+
+	ancestors, err := uc.categoryRepo.GetAncestors(ctx, categoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(ancestors))
+	for _, a := range ancestors {
+		ids = append(ids, a.ID)
+	}
+
+	mappings, err := uc.taxonomyRepo.GetByCategoryIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.resolveEffectiveMappings(categoryID, ids, mappings), nil
+}
+
+func (uc *taxonomyUsecase) Resolve(c context.Context, categoryID, taxonomy string) (domain.EffectiveTaxonomyMapping, error) {
+	if _, err := uc.taxonomy(taxonomy); err != nil {
+		return domain.EffectiveTaxonomyMapping{}, err
+	}
+
+	list, err := uc.GetEffective(c, categoryID)
+	if err != nil {
+		return domain.EffectiveTaxonomyMapping{}, err
+	}
+	for _, m := range list {
+		if m.Taxonomy == taxonomy {
+			return m, nil
+		}
+	}
+
+	return domain.EffectiveTaxonomyMapping{}, fmt.Errorf("%w: neither the category nor its ancestors are mapped to %s", domain.ErrNotFound, taxonomy)
+}
+
+// resolveEffectiveMappings walks the category chain from the root down and
+// lets mappings on deeper categories override those made higher up. Mappings
+// into taxonomies that are no longer loaded are dropped.
+func (uc *taxonomyUsecase) resolveEffectiveMappings(categoryID string, chain []string, mappings []domain.TaxonomyMapping) []domain.EffectiveTaxonomyMapping {
+	byCategory := make(map[string][]domain.TaxonomyMapping)
+	for _, m := range mappings {
+		byCategory[m.CategoryID] = append(byCategory[m.CategoryID], m)
+	}
+
+	resolved := make(map[string]domain.EffectiveTaxonomyMapping)
+	for _, id := range chain {
+		for _, m := range byCategory[id] {
+			t, ok := uc.taxonomies[m.Taxonomy]
+			if !ok {
+				continue
+			}
+			node, _ := t.Lookup(m.ExternalID)
+			resolved[m.Taxonomy] = domain.EffectiveTaxonomyMapping{
+				CategoryID:    categoryID,
+				Taxonomy:      m.Taxonomy,
+				ExternalID:    m.ExternalID,
+				ExternalPath:  node.Path,
+				InheritedFrom: id,
+			}
+		}
+	}
+
+	result := make([]domain.EffectiveTaxonomyMapping, 0, len(resolved))
+	for _, m := range resolved {
+		result = append(result, m)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Taxonomy < result[j].Taxonomy })
+
+	return result
+}
+
+func (uc *taxonomyUsecase) Unmapped(c context.Context, taxonomy string) ([]domain.Category, error) {
+	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
+	defer cancel()
+
+	t, err := uc.taxonomy(taxonomy)
+	if err != nil {
+		return nil, err
+	}
+
+	tree, err := uc.categoryRepo.GetTree(ctx, 0, false)
+	if err != nil {
+		return nil, err
+	}
+
+	mappings, err := uc.taxonomyRepo.FetchByTaxonomy(ctx, taxonomy)
+	if err != nil {
+		return nil, err
+	}
+
+	// GetTree lists parents before children, so each category can take the
+	// code of its parent unless it has one of its own.
+	codes := make(map[string]string, len(tree))
+	for _, m := range mappings {
+		codes[m.CategoryID] = m.ExternalID
+	}
+	hasChildren := make(map[string]bool)
+	for _, cat := range tree {
+		if cat.ParentID == nil {
+			continue
+		}
+		hasChildren[*cat.ParentID] = true
+		if _, own := codes[cat.ID]; !own {
+			if code, ok := codes[*cat.ParentID]; ok {
+				codes[cat.ID] = code
+			}
+		}
+	}
+
+	list := make([]domain.Category, 0)
+	for _, cat := range tree {
+		if hasChildren[cat.ID] {
+			continue
+		}
+		if _, ok := t.Lookup(codes[cat.ID]); !ok {
+			list = append(list, cat)
+		}
+	}
+
+	return list, nil
+}
@@ -0,0 +1,146 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tokobapak/catalog-service/internal/domain"
+	"github.com/tokobapak/catalog-service/pkg/taxonomy"
+)
+
+type stubTaxonomyRepo struct {
+	domain.TaxonomyRepository
+	mappings []domain.TaxonomyMapping
+}
+
+func (s *stubTaxonomyRepo) GetByCategoryIDs(_ context.Context, ids []string) ([]domain.TaxonomyMapping, error) {
+	var list []domain.TaxonomyMapping
+	for _, m := range s.mappings {
+		for _, id := range ids {
+			if m.CategoryID == id {
+				list = append(list, m)
+			}
+		}
+	}
+	return list, nil
+}
+
+func (s *stubTaxonomyRepo) FetchByTaxonomy(_ context.Context, name string) ([]domain.TaxonomyMapping, error) {
+	var list []domain.TaxonomyMapping
+	for _, m := range s.mappings {
+		if m.Taxonomy == name {
+			list = append(list, m)
+		}
+	}
+	return list, nil
+}
+
+// stubTaxonomyTree serves elektronik > handphone > {android, ios} and fashion.
+type stubTaxonomyTree struct {
+	domain.CategoryRepository
+}
+
+func (stubTaxonomyTree) GetTree(context.Context, int, bool) ([]domain.Category, error) {
+	elektronik, handphone := "elektronik", "handphone"
+	return []domain.Category{
+		{ID: "elektronik"},
+		{ID: "fashion"},
+		{ID: "handphone", ParentID: &elektronik},
+		{ID: "android", ParentID: &handphone},
+		{ID: "ios", ParentID: &handphone},
+	}, nil
+}
+
+func (s stubTaxonomyTree) GetAncestors(ctx context.Context, id string) ([]domain.Category, error) {
+	tree, _ := s.GetTree(ctx, 0, false)
+	var chain []domain.Category
+	for id != "" {
+		found := false
+		for _, c := range tree {
+			if c.ID == id {
+				chain = append([]domain.Category{c}, chain...)
+				id, found = "", true
+				if c.ParentID != nil {
+					id = *c.ParentID
+				}
+			}
+		}
+		if !found {
+			return nil, domain.ErrNotFound
+		}
+	}
+	return chain, nil
+}
+
+func newTestTaxonomyUsecase(t *testing.T, mappings ...domain.TaxonomyMapping) domain.TaxonomyUsecase {
+	google, err := taxonomy.Parse("google", strings.NewReader("222 - Electronics\n267 - Electronics > Communications > Telephony > Mobile Phones\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewTaxonomyUsecase(&stubTaxonomyRepo{mappings: mappings}, stubTaxonomyTree{}, map[string]*taxonomy.Taxonomy{"google": google}, time.Second)
+}
+
+func TestResolveInheritsNearestMapping(t *testing.T) {
+	uc := newTestTaxonomyUsecase(t,
+		domain.TaxonomyMapping{CategoryID: "elektronik", Taxonomy: "google", ExternalID: "222"},
+		domain.TaxonomyMapping{CategoryID: "handphone", Taxonomy: "google", ExternalID: "267"},
+		domain.TaxonomyMapping{CategoryID: "handphone", Taxonomy: "retired", ExternalID: "1"},
+	)
+	ctx := context.Background()
+
+	m, err := uc.Resolve(ctx, "android", "google")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.ExternalID != "267" || m.InheritedFrom != "handphone" || m.CategoryID != "android" || !strings.HasSuffix(m.ExternalPath, "Mobile Phones") {
+		t.Fatalf("expected the code of handphone, got %+v", m)
+	}
+
+	list, err := uc.GetEffective(ctx, "android")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 1 {
+		t.Fatalf("expected taxonomies that are not loaded to be left out, got %+v", list)
+	}
+
+	if _, err := uc.Resolve(ctx, "fashion", "google"); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for an unmapped category, got %v", err)
+	}
+	if _, err := uc.Resolve(ctx, "android", "shopee"); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for an unknown taxonomy, got %v", err)
+	}
+}
+
+func TestUnmappedListsLeaves(t *testing.T) {
+	uc := newTestTaxonomyUsecase(t,
+		domain.TaxonomyMapping{CategoryID: "handphone", Taxonomy: "google", ExternalID: "267"},
+		// A code the taxonomy file no longer has counts as unmapped.
+		domain.TaxonomyMapping{CategoryID: "ios", Taxonomy: "google", ExternalID: "999"},
+	)
+
+	list, err := uc.Unmapped(context.Background(), "google")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var ids []string
+	for _, c := range list {
+		ids = append(ids, c.ID)
+	}
+	if got := strings.Join(ids, ","); got != "fashion,ios" {
+		t.Fatalf("expected fashion and ios, got %s", got)
+	}
+}
+
+func TestPutChecksExternalID(t *testing.T) {
+	uc := newTestTaxonomyUsecase(t)
+
+	err := uc.Put(context.Background(), &domain.TaxonomyMapping{CategoryID: "android", Taxonomy: "google", ExternalID: "999"})
+	if !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("expected ErrValidation for an unknown code, got %v", err)
+	}
+}
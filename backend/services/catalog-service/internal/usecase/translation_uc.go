@@ -0,0 +1,225 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"golang.org/x/text/language"
+
+	"github.com/tokobapak/catalog-service/internal/domain"
+	"github.com/tokobapak/catalog-service/pkg/validator"
+)
+
+var defaultLocaleTag = language.MustParse(domain.DefaultLocale)
+
+type translationUsecase struct {
+	translationRepo domain.TranslationRepository
+	categoryRepo    domain.CategoryRepository
+	brandRepo       domain.BrandRepository
+	contextTimeout  time.Duration
+}
+
+func NewTranslationUsecase(t domain.TranslationRepository, c domain.CategoryRepository, b domain.BrandRepository, timeout time.Duration) domain.TranslationUsecase {
+	return &translationUsecase{
+		translationRepo: t,
+		categoryRepo:    c,
+		brandRepo:       b,
+		contextTimeout:  timeout,
+	}
+}
+
+func (uc *translationUsecase) Fetch(c context.Context, kind domain.TranslatableKind, id string) ([]domain.Translation, error) {
+	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
+	defer cancel()
+
+	if err := uc.checkOwner(ctx, kind, id); err != nil {
+		return nil, err
+	}
+
+	byOwner, err := uc.translationRepo.GetByOwners(ctx, kind, []string{id})
+	if err != nil {
+		return nil, err
+	}
+
+	if list := byOwner[id]; list != nil {
+		return list, nil
+	}
+	return []domain.Translation{}, nil
+}
+
+func (uc *translationUsecase) Put(c context.Context, kind domain.TranslatableKind, id string, t *domain.Translation) error {
+	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
+	defer cancel()
+
+	locale, err := canonicalLocale(t.Locale)
+	if err != nil {
+		return err
+	}
+	if locale == domain.DefaultLocale {
+		return fmt.Errorf("%w: %s is the default locale; edit the %s itself", domain.ErrBadParamInput, locale, kind)
+	}
+
+	t.Locale = locale
+	var v validator.Validator
+	checkName(&v, &t.Name)
+	v.Check(kind != domain.TranslatableBrand || t.Description == "", "description", validator.CodeInvalid, "brands have no description to translate")
+	if err := invalid(&v); err != nil {
+		return err
+	}
+
+	if err := uc.checkOwner(ctx, kind, id); err != nil {
+		return err
+	}
+
+	t.UpdatedAt = time.Now()
+	return uc.translationRepo.Upsert(ctx, kind, id, t)
+}
+
+func (uc *translationUsecase) Delete(c context.Context, kind domain.TranslatableKind, id, locale string) error {
+	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
+	defer cancel()
+
+	locale, err := canonicalLocale(locale)
+	if err != nil {
+		return err
+	}
+
+	return uc.translationRepo.Delete(ctx, kind, id, locale)
+}
+
+func (uc *translationUsecase) LocalizeCategories(c context.Context, acceptLanguage string, list []domain.Category) error {
+	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
+	defer cancel()
+
+	var ids []string
+	var collect func([]domain.Category)
+	collect = func(list []domain.Category) {
+		for _, c := range list {
+			ids = append(ids, c.ID)
+			collect(c.Children)
+		}
+	}
+	collect(list)
+
+	byOwner, err := uc.translationsFor(ctx, domain.TranslatableCategory, acceptLanguage, ids)
+	if err != nil {
+		return err
+	}
+
+	prefs := parseAcceptLanguage(acceptLanguage)
+	var apply func([]domain.Category)
+	apply = func(list []domain.Category) {
+		for i := range list {
+			c := &list[i]
+			c.Locale = domain.DefaultLocale
+			if t, ok := bestTranslation(prefs, byOwner[c.ID]); ok {
+				c.Name = t.Name
+				if t.Description != "" {
+					c.Description = t.Description
+				}
+				c.Locale = t.Locale
+			}
+			apply(c.Children)
+		}
+	}
+	apply(list)
+
+	return nil
+}
+
+func (uc *translationUsecase) LocalizeBrands(c context.Context, acceptLanguage string, list []domain.Brand) error {
+	ctx, cancel := context.WithTimeout(c, uc.contextTimeout)
+	defer cancel()
+
+	ids := make([]string, len(list))
+	for i, b := range list {
+		ids[i] = b.ID
+	}
+
+	byOwner, err := uc.translationsFor(ctx, domain.TranslatableBrand, acceptLanguage, ids)
+	if err != nil {
+		return err
+	}
+
+	prefs := parseAcceptLanguage(acceptLanguage)
+	for i := range list {
+		b := &list[i]
+		b.Locale = domain.DefaultLocale
+		if t, ok := bestTranslation(prefs, byOwner[b.ID]); ok {
+			b.Name = t.Name
+			b.Locale = t.Locale
+		}
+	}
+
+	return nil
+}
+
+// translationsFor loads the translations of ids, skipping the query when the
+// client has no language preference to honour.
+func (uc *translationUsecase) translationsFor(ctx context.Context, kind domain.TranslatableKind, acceptLanguage string, ids []string) (map[string][]domain.Translation, error) {
+	if len(ids) == 0 || len(parseAcceptLanguage(acceptLanguage)) == 0 {
+		return nil, nil
+	}
+	return uc.translationRepo.GetByOwners(ctx, kind, ids)
+}
+
+func (uc *translationUsecase) checkOwner(ctx context.Context, kind domain.TranslatableKind, id string) error {
+	switch kind {
+	case domain.TranslatableCategory:
+		_, err := uc.categoryRepo.GetByID(ctx, id)
+		return err
+	case domain.TranslatableBrand:
+		_, err := uc.brandRepo.GetByID(ctx, id)
+		return err
+	}
+	return fmt.Errorf("%w: unknown translatable kind %q", domain.ErrBadParamInput, kind)
+}
+
+// canonicalLocale validates a BCP 47 locale and returns its canonical spelling,
+// so "en-us" and "EN-US" are stored as "en-US".
+func canonicalLocale(locale string) (string, error) {
+	tag, err := language.Parse(locale)
+	if err != nil || tag == language.Und {
+		return "", fmt.Errorf("%w: invalid locale %q", domain.ErrBadParamInput, locale)
+	}
+	return tag.String(), nil
+}
+
+// parseAcceptLanguage returns the client's language preferences in order. A
+// malformed header counts as no preference.
+func parseAcceptLanguage(header string) []language.Tag {
+	if header == "" {
+		return nil
+	}
+	tags, _, err := language.ParseAcceptLanguage(header)
+	if err != nil {
+		return nil
+	}
+	return tags
+}
+
+// bestTranslation picks the translation that best serves prefs, or reports
+// false when the default locale serves them at least as well.
+func bestTranslation(prefs []language.Tag, available []domain.Translation) (domain.Translation, bool) {
+	if len(prefs) == 0 || len(available) == 0 {
+		return domain.Translation{}, false
+	}
+
+	supported := []language.Tag{defaultLocaleTag}
+	candidates := []domain.Translation{{}}
+	for _, t := range available {
+		tag, err := language.Parse(t.Locale)
+		if err != nil {
+			continue
+		}
+		supported = append(supported, tag)
+		candidates = append(candidates, t)
+	}
+
+	_, idx, confidence := language.NewMatcher(supported).Match(prefs...)
+	if confidence == language.No || idx == 0 {
+		return domain.Translation{}, false
+	}
+	return candidates[idx], true
+}
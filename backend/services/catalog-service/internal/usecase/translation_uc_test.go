@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/tokobapak/catalog-service/internal/domain"
+)
+
+func TestBestTranslation(t *testing.T) {
+	available := []domain.Translation{
+		{Locale: "en-US", Name: "Electronics"},
+		{Locale: "zh-CN", Name: "电子产品"},
+	}
+
+	tests := []struct {
+		acceptLanguage string
+		want           string
+	}{
+		{"en", "Electronics"},
+		{"en-GB,en;q=0.8", "Electronics"},
+		{"id-ID,en;q=0.5", ""},
+		{"zh-Hans", "电子产品"},
+		{"fr-FR", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		got, ok := bestTranslation(parseAcceptLanguage(tt.acceptLanguage), available)
+		if tt.want == "" {
+			if ok {
+				t.Errorf("%q: expected the default locale, got %q", tt.acceptLanguage, got.Name)
+			}
+			continue
+		}
+		if !ok || got.Name != tt.want {
+			t.Errorf("%q: expected %q, got %q (%v)", tt.acceptLanguage, tt.want, got.Name, ok)
+		}
+	}
+}
+
+func TestCanonicalLocale(t *testing.T) {
+	got, err := canonicalLocale("en-us")
+	if err != nil || got != "en-US" {
+		t.Fatalf("expected en-US, got %q, %v", got, err)
+	}
+
+	if _, err := canonicalLocale("not a locale"); err == nil {
+		t.Fatal("expected an invalid locale to be rejected")
+	}
+}
@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tokobapak/catalog-service/internal/domain"
+	"github.com/tokobapak/catalog-service/pkg/slug"
+	"github.com/tokobapak/catalog-service/pkg/validator"
+)
+
+// maxNameLength matches the width of the name and slug columns.
+const maxNameLength = 255
+
+// invalid turns the failures collected by v into an ErrValidation, or
+// returns nil when there were none.
+func invalid(v *validator.Validator) error {
+	if err := v.Err(); err != nil {
+		return fmt.Errorf("%w: %w", domain.ErrValidation, err)
+	}
+	return nil
+}
+
+// validateCategory checks the fields of a category about to be stored,
+// trimming its name. Whether the parent exists is checked by checkPlacement.
+func validateCategory(m *domain.Category) error {
+	var v validator.Validator
+	checkName(&v, &m.Name)
+	checkSlug(&v, m.Slug)
+	if m.ParentID != nil {
+		v.Check(validator.NotBlank(*m.ParentID), "parentId", validator.CodeRequired, "must not be empty, use null for a top-level category")
+	}
+	v.Check(m.DisplayOrder >= 0, "displayOrder", validator.CodeMin, "must not be negative")
+	checkURL(&v, m.ImageURL, "imageUrl")
+	checkURL(&v, m.IconURL, "iconUrl")
+	if m.VisibleFrom != nil && m.VisibleUntil != nil {
+		v.Check(m.VisibleUntil.After(*m.VisibleFrom), "visibleUntil", validator.CodeInvalid, "must be after visibleFrom")
+	}
+	return invalid(&v)
+}
+
+// validateBrand checks the fields of a brand about to be stored, trimming its
+// name.
+func validateBrand(m *domain.Brand) error {
+	var v validator.Validator
+	checkName(&v, &m.Name)
+	checkSlug(&v, m.Slug)
+	checkURL(&v, m.LogoURL, "logoUrl")
+	return invalid(&v)
+}
+
+func checkName(v *validator.Validator, name *string) {
+	*name = strings.TrimSpace(*name)
+	v.Check(validator.NotBlank(*name), "name", validator.CodeRequired, "must not be empty")
+	v.Check(validator.MaxLength(*name, maxNameLength), "name", validator.CodeTooLong, fmt.Sprintf("must be at most %d characters", maxNameLength))
+}
+
+// checkSlug accepts an empty slug, which is derived from the name or kept.
+func checkSlug(v *validator.Validator, s string) {
+	if s == "" {
+		return
+	}
+	v.Check(slug.Make(s) != "", "slug", validator.CodeInvalid, "must contain letters or digits")
+	v.Check(validator.MaxLength(s, maxNameLength), "slug", validator.CodeTooLong, fmt.Sprintf("must be at most %d characters", maxNameLength))
+}
+
+func checkURL(v *validator.Validator, u *string, field string) {
+	if u != nil {
+		v.Check(validator.HTTPURL(*u), field, validator.CodeInvalid, "must be an absolute http or https URL")
+	}
+}
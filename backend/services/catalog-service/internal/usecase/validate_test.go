@@ -0,0 +1,48 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/tokobapak/catalog-service/internal/domain"
+	"github.com/tokobapak/catalog-service/pkg/validator"
+)
+
+func TestValidateCategoryListsEveryField(t *testing.T) {
+	empty, relative := " ", "/img/a.png"
+	c := domain.Category{Name: "  ", Slug: "???", ParentID: &empty, DisplayOrder: -1, ImageURL: &relative}
+
+	err := validateCategory(&c)
+	if !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("expected ErrValidation, got %v", err)
+	}
+
+	var errs validator.Errors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected field errors in %v", err)
+	}
+	var fields []string
+	for _, fe := range errs {
+		fields = append(fields, fe.Field)
+	}
+	if got := strings.Join(fields, ","); got != "name,slug,parentId,displayOrder,imageUrl" {
+		t.Fatalf("unexpected fields %s", got)
+	}
+}
+
+func TestValidateBrandTrimsName(t *testing.T) {
+	logo := "https://cdn.example.com/logo.png"
+	b := domain.Brand{Name: "  Polytron ", LogoURL: &logo}
+	if err := validateBrand(&b); err != nil {
+		t.Fatal(err)
+	}
+	if b.Name != "Polytron" {
+		t.Fatalf("expected the name to be trimmed, got %q", b.Name)
+	}
+
+	b.Name = strings.Repeat("a", maxNameLength+1)
+	if err := validateBrand(&b); !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("expected an overlong name to fail, got %v", err)
+	}
+}
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS attributes (
+    id VARCHAR(36) PRIMARY KEY,
+    code VARCHAR(100) UNIQUE NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('text', 'number', 'enum', 'boolean', 'unit')),
+    allowed_values TEXT[] NOT NULL DEFAULT '{}',
+    is_required BOOLEAN NOT NULL DEFAULT FALSE,
+    is_filterable BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS category_attributes (
+    category_id VARCHAR(36) NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    attribute_id VARCHAR(36) NOT NULL REFERENCES attributes(id) ON DELETE CASCADE,
+    is_required BOOLEAN,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (category_id, attribute_id)
+);
+
+CREATE INDEX idx_category_attributes_attribute_id ON category_attributes(attribute_id);
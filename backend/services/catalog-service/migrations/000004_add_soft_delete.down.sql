@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_brands_deleted_at;
+DROP INDEX IF EXISTS idx_categories_live_parent_id;
+
+ALTER TABLE categories DROP CONSTRAINT IF EXISTS categories_parent_id_fkey;
+ALTER TABLE categories ADD CONSTRAINT categories_parent_id_fkey
+    FOREIGN KEY (parent_id) REFERENCES categories(id) ON DELETE SET NULL;
+
+ALTER TABLE brands DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE categories DROP COLUMN IF EXISTS deleted_at;
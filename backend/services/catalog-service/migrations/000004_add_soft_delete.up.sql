@@ -0,0 +1,10 @@
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE brands ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+
+-- Children are handled explicitly by the delete policy; never promote them to roots silently.
+ALTER TABLE categories DROP CONSTRAINT IF EXISTS categories_parent_id_fkey;
+ALTER TABLE categories ADD CONSTRAINT categories_parent_id_fkey
+    FOREIGN KEY (parent_id) REFERENCES categories(id) ON DELETE RESTRICT;
+
+CREATE INDEX idx_categories_live_parent_id ON categories(parent_id, display_order) WHERE deleted_at IS NULL;
+CREATE INDEX idx_brands_deleted_at ON brands(deleted_at);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_brands_created_at_id;
+DROP INDEX IF EXISTS idx_categories_created_at_id;
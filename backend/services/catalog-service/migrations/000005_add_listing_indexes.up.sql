@@ -0,0 +1,3 @@
+-- Keyset pagination walks listings by (created_at, id).
+CREATE INDEX IF NOT EXISTS idx_categories_created_at_id ON categories(created_at, id);
+CREATE INDEX IF NOT EXISTS idx_brands_created_at_id ON brands(created_at, id);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_brands_slug_trgm;
+DROP INDEX IF EXISTS idx_brands_name_trgm;
+DROP INDEX IF EXISTS idx_categories_slug_trgm;
+DROP INDEX IF EXISTS idx_categories_name_trgm;
@@ -0,0 +1,7 @@
+-- Trigram indexes back both the prefix (ILIKE) and similarity (%) search on list endpoints.
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS idx_categories_name_trgm ON categories USING gin (name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_categories_slug_trgm ON categories USING gin (slug gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_brands_name_trgm ON brands USING gin (name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_brands_slug_trgm ON brands USING gin (slug gin_trgm_ops);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS brand_slug_history;
+DROP TABLE IF EXISTS category_slug_history;
@@ -0,0 +1,16 @@
+-- Slugs a category or brand used before it was renamed, so old URLs keep resolving.
+CREATE TABLE IF NOT EXISTS category_slug_history (
+    slug VARCHAR(255) PRIMARY KEY,
+    category_id VARCHAR(36) NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_category_slug_history_category_id ON category_slug_history(category_id);
+
+CREATE TABLE IF NOT EXISTS brand_slug_history (
+    slug VARCHAR(255) PRIMARY KEY,
+    brand_id VARCHAR(36) NOT NULL REFERENCES brands(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_brand_slug_history_brand_id ON brand_slug_history(brand_id);
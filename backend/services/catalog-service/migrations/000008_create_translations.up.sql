@@ -0,0 +1,17 @@
+-- Names and descriptions in locales other than the default id-ID, which stays on the entity itself.
+CREATE TABLE IF NOT EXISTS category_translations (
+    category_id VARCHAR(36) NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    locale VARCHAR(35) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (category_id, locale)
+);
+
+CREATE TABLE IF NOT EXISTS brand_translations (
+    brand_id VARCHAR(36) NOT NULL REFERENCES brands(id) ON DELETE CASCADE,
+    locale VARCHAR(35) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (brand_id, locale)
+);
@@ -0,0 +1,15 @@
+-- Audit trail of every mutation of a category or brand, written in the same
+-- transaction as the change.
+CREATE TABLE IF NOT EXISTS revisions (
+    id BIGSERIAL PRIMARY KEY,
+    entity_type VARCHAR(50) NOT NULL,
+    entity_id VARCHAR(36) NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    actor VARCHAR(255) NOT NULL,
+    before_state JSONB,
+    after_state JSONB NOT NULL,
+    diff JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_revisions_entity ON revisions(entity_type, entity_id, created_at DESC, id DESC);
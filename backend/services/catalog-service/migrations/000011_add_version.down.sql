@@ -0,0 +1,2 @@
+ALTER TABLE brands DROP COLUMN IF EXISTS version;
+ALTER TABLE categories DROP COLUMN IF EXISTS version;
@@ -0,0 +1,3 @@
+-- Incremented by every write so clients can detect concurrent edits (ETag / If-Match).
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE brands ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
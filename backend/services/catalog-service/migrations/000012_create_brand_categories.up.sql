@@ -0,0 +1,9 @@
+-- Which categories a brand sells in, for storefront brand listings.
+CREATE TABLE IF NOT EXISTS brand_categories (
+    brand_id VARCHAR(36) NOT NULL REFERENCES brands(id) ON DELETE CASCADE,
+    category_id VARCHAR(36) NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (brand_id, category_id)
+);
+
+CREATE INDEX idx_brand_categories_category_id ON brand_categories(category_id);
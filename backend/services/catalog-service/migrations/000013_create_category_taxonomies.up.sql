@@ -0,0 +1,11 @@
+-- Codes of categories in external taxonomies, such as marketplace category
+-- trees, keyed by the name of the taxonomy file.
+CREATE TABLE IF NOT EXISTS category_taxonomies (
+    category_id VARCHAR(36) NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    taxonomy VARCHAR(50) NOT NULL,
+    external_id VARCHAR(50) NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (category_id, taxonomy)
+);
+
+CREATE INDEX idx_category_taxonomies_taxonomy ON category_taxonomies(taxonomy);
@@ -0,0 +1,19 @@
+-- btree_gist lets the exclusion constraint compare category_id with =.
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+-- Commission and fixed fee charged to sellers per category, for a period.
+CREATE TABLE IF NOT EXISTS fee_rules (
+    id VARCHAR(36) PRIMARY KEY,
+    category_id VARCHAR(36) NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    commission_basis_points INTEGER NOT NULL CHECK (commission_basis_points BETWEEN 0 AND 10000),
+    fixed_fee BIGINT NOT NULL DEFAULT 0 CHECK (fixed_fee >= 0),
+    effective_from TIMESTAMP WITH TIME ZONE NOT NULL,
+    effective_to TIMESTAMP WITH TIME ZONE CHECK (effective_to > effective_from),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    -- The rules of a category must not overlap; a NULL effective_to is unbounded.
+    CONSTRAINT fee_rules_no_overlap EXCLUDE USING gist (
+        category_id WITH =,
+        tstzrange(effective_from, effective_to) WITH &&
+    )
+);
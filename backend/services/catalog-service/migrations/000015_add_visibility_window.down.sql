@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_categories_visibility_window;
+ALTER TABLE categories DROP CONSTRAINT IF EXISTS categories_visibility_window;
+ALTER TABLE categories DROP COLUMN IF EXISTS window_open;
+ALTER TABLE categories DROP COLUMN IF EXISTS visible_until;
+ALTER TABLE categories DROP COLUMN IF EXISTS visible_from;
@@ -0,0 +1,10 @@
+-- Scheduled visibility of campaign categories. window_open records whether the
+-- window was open at the last write or scheduler run, so the scheduler can
+-- announce each opening and closing once.
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS visible_from TIMESTAMP WITH TIME ZONE;
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS visible_until TIMESTAMP WITH TIME ZONE;
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS window_open BOOLEAN NOT NULL DEFAULT TRUE;
+ALTER TABLE categories ADD CONSTRAINT categories_visibility_window CHECK (visible_until > visible_from);
+
+CREATE INDEX IF NOT EXISTS idx_categories_visibility_window ON categories(visible_from, visible_until)
+    WHERE visible_from IS NOT NULL OR visible_until IS NOT NULL;
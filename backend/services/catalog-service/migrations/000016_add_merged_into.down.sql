@@ -0,0 +1,2 @@
+ALTER TABLE brands DROP COLUMN IF EXISTS merged_into;
+ALTER TABLE categories DROP COLUMN IF EXISTS merged_into;
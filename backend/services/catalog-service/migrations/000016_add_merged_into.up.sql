@@ -0,0 +1,4 @@
+-- The survivor a duplicate category or brand was merged into, kept on the
+-- soft-deleted duplicate so references to it can still be remapped.
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS merged_into VARCHAR(36) REFERENCES categories(id) ON DELETE SET NULL;
+ALTER TABLE brands ADD COLUMN IF NOT EXISTS merged_into VARCHAR(36) REFERENCES brands(id) ON DELETE SET NULL;
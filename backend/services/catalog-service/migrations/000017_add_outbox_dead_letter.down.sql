@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_outbox_events_published;
+
+DROP INDEX IF EXISTS idx_outbox_events_pending;
+DROP INDEX IF EXISTS idx_outbox_events_pending_aggregate;
+CREATE INDEX idx_outbox_events_pending ON outbox_events(id) WHERE published_at IS NULL;
+CREATE INDEX idx_outbox_events_pending_aggregate ON outbox_events(aggregate_type, aggregate_id, id) WHERE published_at IS NULL;
+
+ALTER TABLE outbox_events DROP COLUMN IF EXISTS dead_at;
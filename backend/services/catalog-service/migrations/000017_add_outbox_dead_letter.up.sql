@@ -0,0 +1,11 @@
+-- Events the relay gave up on after too many failed attempts. They no longer
+-- hold back the later events of their aggregate and stay until requeued.
+ALTER TABLE outbox_events ADD COLUMN IF NOT EXISTS dead_at TIMESTAMP WITH TIME ZONE;
+
+DROP INDEX IF EXISTS idx_outbox_events_pending;
+DROP INDEX IF EXISTS idx_outbox_events_pending_aggregate;
+CREATE INDEX idx_outbox_events_pending ON outbox_events(id) WHERE published_at IS NULL AND dead_at IS NULL;
+CREATE INDEX idx_outbox_events_pending_aggregate ON outbox_events(aggregate_type, aggregate_id, id) WHERE published_at IS NULL AND dead_at IS NULL;
+
+-- Published events are deleted once they are older than the retention period.
+CREATE INDEX IF NOT EXISTS idx_outbox_events_published ON outbox_events(published_at) WHERE published_at IS NOT NULL;
@@ -0,0 +1,72 @@
+// Package slug derives URL slugs from catalog names.
+package slug
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// MaxLength keeps slugs, including any numeric suffix, well inside the
+// VARCHAR(255) slug columns.
+const MaxLength = 200
+
+// words are spelled out in Indonesian rather than dropped as punctuation, so
+// "Ibu & Anak" becomes "ibu-dan-anak".
+var words = strings.NewReplacer(
+	"&", " dan ",
+	"+", " plus ",
+	"@", " at ",
+)
+
+// Make lowercases name, folds diacritics to their ASCII base letters and
+// joins the remaining runs of letters and digits with hyphens. It returns ""
+// when nothing usable is left.
+func Make(name string) string {
+	folded, _, err := transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), words.Replace(name))
+	if err != nil {
+		folded = name
+	}
+
+	var b strings.Builder
+	pendingDash := false
+	for _, r := range strings.ToLower(folded) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			if pendingDash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			pendingDash = false
+			b.WriteRune(r)
+		case r == '\'' || r == '’':
+			// Apostrophes join words: "Levi's" becomes "levis".
+		default:
+			pendingDash = true
+		}
+	}
+
+	return truncate(b.String(), MaxLength)
+}
+
+// WithSuffix returns the n-th candidate for base: base itself for n <= 1 and
+// base-n otherwise, shortening base so the result stays within MaxLength.
+func WithSuffix(base string, n int) string {
+	if n <= 1 {
+		return base
+	}
+	suffix := "-" + strconv.Itoa(n)
+	return truncate(base, MaxLength-len(suffix)) + suffix
+}
+
+// truncate cuts s to at most max bytes without leaving a trailing hyphen.
+// Slugs are ASCII, so bytes and runes coincide.
+func truncate(s string, max int) string {
+	if len(s) > max {
+		s = s[:max]
+	}
+	return strings.TrimRight(s, "-")
+}
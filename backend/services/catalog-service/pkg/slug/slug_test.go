@@ -0,0 +1,44 @@
+package slug
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMake(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Elektronik", "elektronik"},
+		{"Handphone & Tablet", "handphone-dan-tablet"},
+		{"  Ibu   &  Anak ", "ibu-dan-anak"},
+		{"Kesehatan + Kecantikan", "kesehatan-plus-kecantikan"},
+		{"Crème Brûlée", "creme-brulee"},
+		{"Levi's", "levis"},
+		{"Kaos Pria (Lengan Panjang)", "kaos-pria-lengan-panjang"},
+		{"Smart TV 4K/8K", "smart-tv-4k-8k"},
+		{"!!!", ""},
+		{"日本", ""},
+	}
+
+	for _, tt := range tests {
+		if got := Make(tt.name); got != tt.want {
+			t.Errorf("Make(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestWithSuffix(t *testing.T) {
+	if got := WithSuffix("elektronik", 1); got != "elektronik" {
+		t.Errorf("expected the first candidate to be the base, got %q", got)
+	}
+	if got := WithSuffix("elektronik", 3); got != "elektronik-3" {
+		t.Errorf("expected elektronik-3, got %q", got)
+	}
+
+	long := strings.Repeat("a", MaxLength)
+	if got := WithSuffix(long, 12); len(got) != MaxLength || !strings.HasSuffix(got, "-12") {
+		t.Errorf("expected the base to be shortened to fit the suffix, got %d bytes", len(got))
+	}
+}
@@ -0,0 +1,117 @@
+// Package taxonomy reads external product taxonomies, such as the Google
+// product taxonomy, from files listing one category per line as
+// "ID - Top > Middle > Leaf".
+package taxonomy
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Node is one category of an external taxonomy. Path is its full name, with
+// levels separated by " > ".
+type Node struct {
+	ID   string `json:"id"`
+	Path string `json:"path"`
+}
+
+// Taxonomy is a parsed taxonomy file.
+type Taxonomy struct {
+	Name string
+	// Version is taken from a "# ...Version: ..." comment, if the file has one.
+	Version string
+	nodes   map[string]Node
+}
+
+// Lookup returns the node with the given ID.
+func (t *Taxonomy) Lookup(id string) (Node, bool) {
+	n, ok := t.nodes[id]
+	return n, ok
+}
+
+// Len returns the number of nodes in t.
+func (t *Taxonomy) Len() int {
+	return len(t.nodes)
+}
+
+// Parse reads a taxonomy file. Blank lines and lines starting with # are
+// skipped.
+func Parse(name string, r io.Reader) (*Taxonomy, error) {
+	t := &Taxonomy{Name: name, nodes: make(map[string]Node)}
+
+	sc := bufio.NewScanner(r)
+	for line := 1; sc.Scan(); line++ {
+		text := strings.TrimSpace(sc.Text())
+		if line == 1 {
+			text = strings.TrimPrefix(text, "\ufeff")
+		}
+		if text == "" {
+			continue
+		}
+		if strings.HasPrefix(text, "#") {
+			if i := strings.Index(text, "Version:"); i >= 0 {
+				t.Version = strings.TrimSpace(text[i+len("Version:"):])
+			}
+			continue
+		}
+
+		id, path, ok := strings.Cut(text, " - ")
+		id, path = strings.TrimSpace(id), strings.TrimSpace(path)
+		if !ok || id == "" || path == "" || strings.ContainsAny(id, " \t") {
+			return nil, fmt.Errorf("%s:%d: expected \"ID - Path\"", name, line)
+		}
+		if _, dup := t.nodes[id]; dup {
+			return nil, fmt.Errorf("%s:%d: ID %s appears twice", name, line, id)
+		}
+		t.nodes[id] = Node{ID: id, Path: path}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	return t, nil
+}
+
+// Load parses every .txt file in dir, naming each taxonomy after its file:
+// google.txt becomes "google". A missing dir loads nothing.
+func Load(dir string) (map[string]*Taxonomy, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return map[string]*Taxonomy{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]*Taxonomy)
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".txt" {
+			continue
+		}
+
+		name := strings.TrimSuffix(e.Name(), ".txt")
+		t, err := parseFile(name, filepath.Join(dir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		result[name] = t
+	}
+
+	return result, nil
+}
+
+func parseFile(name, path string) (*Taxonomy, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return Parse(name, f)
+}
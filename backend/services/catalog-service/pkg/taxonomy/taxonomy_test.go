@@ -0,0 +1,65 @@
+package taxonomy
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const sample = "\ufeff# Google_Product_Taxonomy_Version: 2021-09-21\n" +
+	"222 - Electronics\n" +
+	"\n" +
+	"267 - Electronics > Communications > Telephony > Mobile Phones\n"
+
+func TestParse(t *testing.T) {
+	tx, err := Parse("google", strings.NewReader(sample))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.Version != "2021-09-21" || tx.Len() != 2 {
+		t.Fatalf("unexpected taxonomy %+v", tx)
+	}
+
+	n, ok := tx.Lookup("267")
+	if !ok || n.Path != "Electronics > Communications > Telephony > Mobile Phones" {
+		t.Fatalf("unexpected node %+v", n)
+	}
+	if _, ok := tx.Lookup("1"); ok {
+		t.Fatal("expected an unknown ID to be missing")
+	}
+}
+
+func TestParseRejectsMalformedLines(t *testing.T) {
+	for _, body := range []string{
+		"222 Electronics\n",
+		"222 - Electronics\n222 - Electronics\n",
+		" - Electronics\n",
+	} {
+		if _, err := Parse("google", strings.NewReader(body)); err == nil {
+			t.Errorf("expected %q to be rejected", body)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "google.txt"), []byte(sample), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("# not a taxonomy\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded) != 1 || loaded["google"].Len() != 2 {
+		t.Fatalf("unexpected taxonomies %v", loaded)
+	}
+
+	if loaded, err := Load(filepath.Join(dir, "missing")); err != nil || len(loaded) != 0 {
+		t.Fatalf("expected a missing directory to load nothing, got %v, %v", loaded, err)
+	}
+}
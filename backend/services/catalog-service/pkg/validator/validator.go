@@ -0,0 +1,83 @@
+// Package validator collects the field-level problems with an input so they
+// can be reported together rather than one request at a time.
+package validator
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// Codes name the kind of rule a field broke, for clients that react to them.
+const (
+	CodeRequired = "required"
+	CodeTooLong  = "too_long"
+	CodeMin      = "min"
+	CodeInvalid  = "invalid"
+	CodeNotFound = "not_found"
+)
+
+// FieldError is one rule a field of the input broke. Field uses the JSON
+// member name.
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Errors lists the rules an input broke, in the order they were checked.
+type Errors []FieldError
+
+func (e Errors) Error() string {
+	parts := make([]string, len(e))
+	for i, fe := range e {
+		parts[i] = fe.Field + ": " + fe.Message
+	}
+	return strings.Join(parts, "; ")
+}
+
+// Validator accumulates FieldErrors. The zero value is ready to use.
+type Validator struct {
+	errs Errors
+}
+
+// Check records a FieldError unless ok.
+func (v *Validator) Check(ok bool, field, code, message string) {
+	if !ok {
+		v.Add(field, code, message)
+	}
+}
+
+func (v *Validator) Add(field, code, message string) {
+	v.errs = append(v.errs, FieldError{Field: field, Code: code, Message: message})
+}
+
+// Err returns the collected Errors, or nil when every check passed.
+func (v *Validator) Err() error {
+	if len(v.errs) == 0 {
+		return nil
+	}
+	return v.errs
+}
+
+// NotBlank reports whether s has anything besides white space.
+func NotBlank(s string) bool {
+	return strings.TrimSpace(s) != ""
+}
+
+// MaxLength reports whether s is at most n characters long.
+func MaxLength(s string, n int) bool {
+	return utf8.RuneCountInString(s) <= n
+}
+
+// HTTPURL reports whether s is an absolute http or https URL.
+func HTTPURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// Matches reports whether s matches re.
+func Matches(s string, re *regexp.Regexp) bool {
+	return re.MatchString(s)
+}
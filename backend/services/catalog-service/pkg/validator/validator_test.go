@@ -0,0 +1,34 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestValidatorCollectsEveryFailure(t *testing.T) {
+	var v Validator
+	v.Check(NotBlank("  "), "name", CodeRequired, "must not be empty")
+	v.Check(MaxLength("héllo", 5), "slug", CodeTooLong, "must be at most 5 characters")
+	v.Check(HTTPURL("/logo.png"), "logoUrl", CodeInvalid, "must be an absolute http or https URL")
+
+	err := v.Err()
+	var errs Errors
+	if !errors.As(fmt.Errorf("wrapped: %w", err), &errs) {
+		t.Fatalf("expected Errors, got %T", err)
+	}
+	if len(errs) != 2 || errs[0].Field != "name" || errs[1].Field != "logoUrl" {
+		t.Fatalf("unexpected errors %+v", errs)
+	}
+	if err.Error() != "name: must not be empty; logoUrl: must be an absolute http or https URL" {
+		t.Fatalf("unexpected message %q", err.Error())
+	}
+}
+
+func TestValidatorWithoutFailures(t *testing.T) {
+	var v Validator
+	v.Check(HTTPURL("https://cdn.example.com/a.png"), "imageUrl", CodeInvalid, "invalid")
+	if err := v.Err(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}